@@ -0,0 +1,126 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// adaptiveBulkMinDelay is the shortest pause between bulk requests, shorter ones are dropped.
+const adaptiveBulkMinDelay = 10 * time.Millisecond
+
+// adaptiveBulkSizer adjusts the number of items per bulk request and the pause between the requests
+// to the backpressure of the cluster. It is shared by the bulk workers.
+type adaptiveBulkSizer struct {
+	minSize       uint64
+	maxSize       uint64
+	targetLatency time.Duration
+	maxDelay      time.Duration
+
+	mu    sync.Mutex
+	size  uint64
+	delay time.Duration
+}
+
+// newAdaptiveBulkSizer returns the sizer starting with the largest bulk requests sent without a pause.
+func newAdaptiveBulkSizer(minSize, maxSize uint64, targetLatency, maxDelay time.Duration) *adaptiveBulkSizer {
+	return &adaptiveBulkSizer{
+		minSize:       minSize,
+		maxSize:       maxSize,
+		targetLatency: targetLatency,
+		maxDelay:      maxDelay,
+		size:          maxSize,
+	}
+}
+
+// limits returns the current number of items per bulk request and the pause before sending it.
+func (s *adaptiveBulkSizer) limits() (uint64, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.size, s.delay
+}
+
+// observe adjusts the limits to the outcome of a bulk request with the given number of items,
+// the number of them rejected by the overloaded cluster and the latency of the request.
+func (s *adaptiveBulkSizer) observe(ctx context.Context, items, rejected int, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	size, delay := s.size, s.delay
+
+	switch {
+	case rejected > 0:
+		// The queues of the cluster are full, so the load is halved and the requests are paced
+		s.size = max(s.minSize, s.size/2)
+		s.delay = min(s.maxDelay, max(2*s.delay, adaptiveBulkMinDelay))
+
+	case latency > s.targetLatency:
+		// The cluster slows down, so the requests are made smaller before it starts rejecting them
+		s.size = max(s.minSize, s.size*3/4)
+
+	default:
+		s.delay /= 2
+		if s.delay < adaptiveBulkMinDelay {
+			s.delay = 0
+		}
+
+		// Only a request using the whole limit shows the cluster can handle more
+		if uint64(items) >= s.size {
+			s.size = min(s.maxSize, s.size+max(1, s.size/4))
+		}
+	}
+
+	if s.size != size || s.delay != delay {
+		sdk.Logger(ctx).Debug().
+			Uint64("bulkSize", s.size).
+			Dur("delay", s.delay).
+			Int("rejected", rejected).
+			Dur("latency", latency).
+			Msg("adjusted adaptive bulk size")
+	}
+}
+
+// bulkLimits returns the number of items per bulk request and the pause before sending it.
+func (d *Destination) bulkLimits() (uint64, time.Duration) {
+	if d.bulkSizer == nil {
+		return d.config.BulkSize, 0
+	}
+
+	return d.bulkSizer.limits()
+}
+
+// observeBulkRequest reports the outcome of a bulk request to the adaptive sizer, if enabled.
+func (d *Destination) observeBulkRequest(ctx context.Context, items []bulkItem, response bulkResponse, latency time.Duration) {
+	if d.bulkSizer == nil {
+		return
+	}
+
+	d.bulkSizer.observe(ctx, len(items), response.rejections(), latency)
+}
+
+// observeRejectedBulkRequest reports the bulk request rejected as a whole by the overloaded cluster
+// to the adaptive sizer, if enabled.
+func (d *Destination) observeRejectedBulkRequest(ctx context.Context, items []bulkItem, latency time.Duration) {
+	if d.bulkSizer == nil {
+		return
+	}
+
+	d.bulkSizer.observe(ctx, len(items), len(items), latency)
+}
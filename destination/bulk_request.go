@@ -0,0 +1,161 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+)
+
+// maxPooledBufferSize is the capacity of the largest buffer kept for reuse, larger ones are left to the GC.
+const maxPooledBufferSize = 128 << 20
+
+// bulkBufferPool holds the buffers the bulk requests are encoded into, reused across writes.
+var bulkBufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
+// getBulkBuffer returns an empty buffer from the pool.
+func getBulkBuffer() *bytes.Buffer {
+	buf := bulkBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+
+	return buf
+}
+
+// putBulkBuffer returns the buffer to the pool. The buffer must not be used afterwards.
+func putBulkBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	bulkBufferPool.Put(buf)
+}
+
+// bulkItem is a single encoded operation of the Bulk API request.
+type bulkItem struct {
+	// record is the position of the Record in the written batch.
+	record int
+	// docKey identifies the target Document. It's empty when the ID is generated by Elasticsearch.
+	docKey string
+	// deletes reports whether the item deletes the Document, so a missing Document is not an error.
+	deletes bool
+	// superseded are the positions of the earlier records whose operations on the Document are compacted into the item.
+	superseded []int
+	// data contains the action and metadata line followed by the optional source line.
+	data []byte
+	// deleteQuery is executed instead of a Bulk API operation, once the preceding items are written.
+	deleteQuery *api.DeleteByQueryRequest
+}
+
+// bulkTarget is the Document targeted by the operation of a record.
+type bulkTarget struct {
+	index   string
+	key     string
+	options api.BulkOperationOptions
+}
+
+// documentKey returns the key identifying a Document across indices.
+func documentKey(index, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	return index + "/" + id
+}
+
+// nextBulkChunk returns the leading items sent in a single bulk request, respecting the bulk size and the maximum request size.
+// An item larger than the maximum request size is sent alone.
+// In the script write mode, the chunk ends before an item operating on a Document already present in it.
+func (d *Destination) nextBulkChunk(items []bulkItem, bulkSize uint64) []bulkItem {
+	var chunkSize uint64
+
+	// Scripts aren't idempotent, so a later operation on a Document isn't sent along with an earlier one,
+	// which would otherwise require sending the applied later operation again when the earlier one is retried.
+	var documents map[string]struct{}
+	if d.config.GetWriteMode() == api.WriteModeScript {
+		documents = make(map[string]struct{})
+	}
+
+	for i, item := range items {
+		itemSize := uint64(len(item.data))
+
+		countExceeded := bulkSize > 0 && uint64(i) >= bulkSize
+		sizeExceeded := d.config.BulkMaxBytes > 0 && chunkSize > 0 && chunkSize+itemSize > d.config.BulkMaxBytes
+
+		var repeated bool
+		if documents != nil && item.docKey != "" {
+			_, repeated = documents[item.docKey]
+			documents[item.docKey] = struct{}{}
+		}
+
+		if countExceeded || sizeExceeded || repeated {
+			return items[:i]
+		}
+
+		chunkSize += itemSize
+	}
+
+	return items
+}
+
+// bulkRequestBody returns the body of the Bulk API request consisting of the items.
+// The items encoded one after another share the underlying array, so their data is used without copying.
+// Otherwise, the data is copied into a pooled buffer, released by the returned function.
+func bulkRequestBody(items []bulkItem) ([]byte, func()) {
+	if body, ok := contiguousData(items); ok {
+		return body, func() {}
+	}
+
+	buf := getBulkBuffer()
+	for _, item := range items {
+		buf.Write(item.data)
+	}
+
+	return buf.Bytes(), func() {
+		putBulkBuffer(buf)
+	}
+}
+
+// contiguousData returns the data of the items as a single slice, if it's adjacent in memory.
+func contiguousData(items []bulkItem) ([]byte, bool) {
+	if len(items) == 0 {
+		return nil, true
+	}
+
+	data := items[0].data
+	for _, item := range items[1:] {
+		if len(item.data) == 0 {
+			continue
+		}
+
+		if cap(data)-len(data) < len(item.data) {
+			return nil, false
+		}
+
+		next := data[:len(data)+len(item.data)]
+		if &next[len(data)] != &item.data[0] {
+			return nil, false
+		}
+
+		data = next
+	}
+
+	return data, true
+}
@@ -14,12 +14,53 @@
 
 package destination
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+)
+
+// bulkResponseFilterPath limits the Bulk API response to the fields needed to handle the failed items.
+// The status of every item is kept, so the items stay in the order of the request.
+var bulkResponseFilterPath = []string{
+	"errors",
+	"items.*._index",
+	"items.*._id",
+	"items.*.status",
+	"items.*.error",
+}
+
+// bulkResponse is the Bulk API response reduced to the failed items.
 type bulkResponse struct {
-	Took   int                 `json:"took"`
-	Errors bool                `json:"errors"`
-	Items  []bulkResponseItems `json:"items"`
+	// Errors reports whether any of the items failed.
+	Errors bool
+	// Items is the number of the items. It's not counted when no item failed.
+	Items int
+	// Failures are the items that were not applied, in the order of the request.
+	Failures []bulkResponseFailure
+}
+
+// rejections returns the number of the items rejected by the cluster being overloaded.
+func (r bulkResponse) rejections() int {
+	var count int
+	for _, failure := range r.Failures {
+		if item, _ := failure.item.result(); item != nil && item.rejected() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// bulkResponseFailure is the failed item of the Bulk API response.
+type bulkResponseFailure struct {
+	// position is the position of the item in the request.
+	position int
+	item     bulkResponseItems
 }
 
 type bulkResponseItems struct {
@@ -30,6 +71,7 @@ type bulkResponseItems struct {
 }
 
 type bulkResponseItem struct {
+	Index  string                 `json:"_index"`
 	ID     string                 `json:"_id"`
 	Status int                    `json:"status"`
 	Error  *bulkResponseItemError `json:"error,omitempty"`
@@ -38,5 +80,160 @@ type bulkResponseItem struct {
 type bulkResponseItemError struct {
 	Type     string          `json:"type"`
 	Reason   string          `json:"reason"`
-	CausedBy json.RawMessage `json:"caused_by"`
+	CausedBy json.RawMessage `json:"caused_by,omitempty"`
+}
+
+// result returns the details of the executed operation along with its type.
+// When the response item contains no details, nil is returned.
+func (i bulkResponseItems) result() (*bulkResponseItem, string) {
+	switch {
+	case i.Index != nil:
+		return i.Index, "index"
+
+	case i.Create != nil:
+		return i.Create, "create"
+
+	case i.Update != nil:
+		return i.Update, "update"
+
+	case i.Delete != nil:
+		return i.Delete, "delete"
+
+	default:
+		return nil, ""
+	}
+}
+
+// succeeded reports whether the operation was applied.
+func (i bulkResponseItem) succeeded() bool {
+	return i.Status >= 200 && i.Status < 300
+}
+
+// retryable reports whether the operation failed temporarily and may succeed when sent again.
+func (i bulkResponseItem) retryable() bool {
+	switch i.Status {
+	case http.StatusServiceUnavailable, http.StatusConflict:
+		return true
+
+	default:
+		return i.rejected()
+	}
+}
+
+// retryableRequestError reports whether the whole Bulk API request failed temporarily,
+// because the cluster was overloaded or unavailable.
+func retryableRequestError(err error) bool {
+	var requestErr *api.BulkRequestError
+	if !errors.As(err, &requestErr) {
+		return false
+	}
+
+	return requestErr.StatusCode == http.StatusTooManyRequests || requestErr.StatusCode == http.StatusServiceUnavailable
+}
+
+// rejected reports whether the operation was rejected by the cluster being overloaded.
+func (i bulkResponseItem) rejected() bool {
+	return i.Status == http.StatusTooManyRequests || (i.Error != nil && i.Error.Type == "es_rejected_execution_exception")
+}
+
+// err returns the error describing the failed operation.
+func (i bulkResponseItem) err(operationType string) error {
+	if i.Error == nil {
+		return fmt.Errorf(
+			"item with key=%s %s failure: unknown error status: %d",
+			i.ID,
+			operationType,
+			i.Status,
+		)
+	}
+
+	return fmt.Errorf(
+		"item with key=%s %s failure: [%s] %s: %s",
+		i.ID,
+		operationType,
+		i.Error.Type,
+		i.Error.Reason,
+		i.Error.CausedBy,
+	)
+}
+
+// decodeBulkResponse decodes the Bulk API response keeping only the failed items.
+// The items are not decoded when the response reports no errors, which precedes them.
+func decodeBulkResponse(reader io.Reader) (bulkResponse, error) {
+	var response bulkResponse
+
+	decoder := json.NewDecoder(reader)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return bulkResponse{}, err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return bulkResponse{}, err
+		}
+
+		switch token {
+		case "errors":
+			if err := decoder.Decode(&response.Errors); err != nil {
+				return bulkResponse{}, err
+			}
+
+			if !response.Errors {
+				return response, nil
+			}
+
+		case "items":
+			if err := decodeBulkResponseItems(decoder, &response); err != nil {
+				return bulkResponse{}, err
+			}
+
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return bulkResponse{}, err
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// decodeBulkResponseItems decodes the items of the Bulk API response one by one, keeping only the failed ones.
+func decodeBulkResponseItems(decoder *json.Decoder, response *bulkResponse) error {
+	if err := expectDelim(decoder, '['); err != nil {
+		return err
+	}
+
+	for ; decoder.More(); response.Items++ {
+		var item bulkResponseItems
+		if err := decoder.Decode(&item); err != nil {
+			return err
+		}
+
+		if result, _ := item.result(); result != nil && result.succeeded() {
+			continue
+		}
+
+		response.Failures = append(response.Failures, bulkResponseFailure{
+			position: response.Items,
+			item:     item,
+		})
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token, which must be the given delimiter.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+
+	return nil
 }
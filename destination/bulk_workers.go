@@ -0,0 +1,98 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// bulkWorkerResult is the outcome of writing a partition of the items.
+type bulkWorkerResult struct {
+	n   int
+	err error
+}
+
+// writeBulkPartitions partitions the items among the bulk workers and writes the partitions concurrently.
+// It returns the number of written records preceding the earliest failed item and its error.
+func (d *Destination) writeBulkPartitions(ctx context.Context, records []opencdc.Record, items []bulkItem) (int, error) {
+	if d.config.BulkWorkers <= 1 {
+		return d.writeBulkChunks(ctx, records, items)
+	}
+
+	partitions := d.partitionBulkItems(items)
+	results := make([]bulkWorkerResult, len(partitions))
+
+	// The first failure stops the other workers before their next bulk request
+	workerCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	for i, partition := range partitions {
+		if len(partition) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			n, err := d.writeBulkChunks(workerCtx, records, partition)
+			if err != nil {
+				cancel(err)
+			}
+
+			results[i] = bulkWorkerResult{n: n, err: err}
+		}()
+	}
+	wg.Wait()
+
+	// Every worker writes its items in order, so all records preceding the earliest unwritten item are written.
+	// The stopped workers report the cancellation, so the error is the failure that stopped them.
+	var failed *bulkWorkerResult
+	for i := range results {
+		if results[i].err != nil && (failed == nil || results[i].n < failed.n) {
+			failed = &results[i]
+		}
+	}
+
+	if failed != nil {
+		return failed.n, context.Cause(workerCtx)
+	}
+
+	return 0, nil
+}
+
+// partitionBulkItems distributes the items among the bulk workers keeping their order.
+// The items of the same Document are assigned to the same worker by the hash of its key.
+func (d *Destination) partitionBulkItems(items []bulkItem) [][]bulkItem {
+	partitions := make([][]bulkItem, d.config.BulkWorkers)
+
+	for _, item := range items {
+		partition := item.record % d.config.BulkWorkers
+		if item.docKey != "" {
+			hash := fnv.New32a()
+			_, _ = hash.Write([]byte(item.docKey))
+			partition = int(hash.Sum32() % uint32(d.config.BulkWorkers))
+		}
+
+		partitions[partition] = append(partitions[partition], item)
+	}
+
+	return partitions
+}
@@ -5,6 +5,7 @@ package destination
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/opencdc"
 	"io"
@@ -21,24 +22,54 @@ var _ client = &clientMock{}
 //
 //		// make and configure a mocked client
 //		mockedclient := &clientMock{
-//			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+//			AliasExistsFunc: func(ctx context.Context, alias string) (bool, error) {
+//				panic("mock out the AliasExists method")
+//			},
+//			BulkFunc: func(ctx context.Context, reader io.Reader, options api.BulkRequestOptions) (io.ReadCloser, error) {
 //				panic("mock out the Bulk method")
 //			},
+//			CreateIndexFunc: func(ctx context.Context, index string, definition api.IndexDefinition) error {
+//				panic("mock out the CreateIndex method")
+//			},
+//			DeleteByQueryFunc: func(ctx context.Context, request *api.DeleteByQueryRequest) (int64, error) {
+//				panic("mock out the DeleteByQuery method")
+//			},
+//			IndexExistsFunc: func(ctx context.Context, index string) (bool, error) {
+//				panic("mock out the IndexExists method")
+//			},
 //			PingFunc: func(ctx context.Context) error {
 //				panic("mock out the Ping method")
 //			},
-//			PrepareCreateOperationFunc: func(item opencdc.Record, index string) (interface{}, interface{}, error) {
+//			PipelineExistsFunc: func(ctx context.Context, name string) (bool, error) {
+//				panic("mock out the PipelineExists method")
+//			},
+//			PrepareCreateOperationFunc: func(item opencdc.Record, index string, options api.BulkOperationOptions) (interface{}, interface{}, error) {
 //				panic("mock out the PrepareCreateOperation method")
 //			},
-//			PrepareDeleteOperationFunc: func(key string, index string) (interface{}, error) {
+//			PrepareDeleteOperationFunc: func(key string, index string, options api.BulkOperationOptions) (interface{}, error) {
 //				panic("mock out the PrepareDeleteOperation method")
 //			},
-//			PrepareUpsertOperationFunc: func(key string, item opencdc.Record, index string) (interface{}, interface{}, error) {
+//			PrepareDiffUpdateOperationFunc: func(key string, index string, diff api.DocumentDiff, options api.BulkOperationOptions) (interface{}, interface{}, error) {
+//				panic("mock out the PrepareDiffUpdateOperation method")
+//			},
+//			PrepareSoftDeleteOperationFunc: func(key string, index string, doc opencdc.StructuredData, options api.BulkOperationOptions) (interface{}, interface{}, error) {
+//				panic("mock out the PrepareSoftDeleteOperation method")
+//			},
+//			PrepareUpsertOperationFunc: func(key string, item opencdc.Record, index string, options api.BulkOperationOptions) (interface{}, interface{}, error) {
 //				panic("mock out the PrepareUpsertOperation method")
 //			},
+//			PutMappingFunc: func(ctx context.Context, index string, mappings json.RawMessage) error {
+//				panic("mock out the PutMapping method")
+//			},
+//			PutTemplateFunc: func(ctx context.Context, template api.Template) error {
+//				panic("mock out the PutTemplate method")
+//			},
 //			SearchFunc: func(ctx context.Context, request *api.SearchRequest) (*api.SearchResponse, error) {
 //				panic("mock out the Search method")
 //			},
+//			TemplateExistsFunc: func(ctx context.Context, template api.Template) (bool, error) {
+//				panic("mock out the TemplateExists method")
+//			},
 //		}
 //
 //		// use mockedclient in code that requires client
@@ -46,44 +77,115 @@ var _ client = &clientMock{}
 //
 //	}
 type clientMock struct {
+	// AliasExistsFunc mocks the AliasExists method.
+	AliasExistsFunc func(ctx context.Context, alias string) (bool, error)
+
 	// BulkFunc mocks the Bulk method.
-	BulkFunc func(ctx context.Context, reader io.Reader) (io.ReadCloser, error)
+	BulkFunc func(ctx context.Context, reader io.Reader, options api.BulkRequestOptions) (io.ReadCloser, error)
+
+	// CreateIndexFunc mocks the CreateIndex method.
+	CreateIndexFunc func(ctx context.Context, index string, definition api.IndexDefinition) error
+
+	// DeleteByQueryFunc mocks the DeleteByQuery method.
+	DeleteByQueryFunc func(ctx context.Context, request *api.DeleteByQueryRequest) (int64, error)
+
+	// IndexExistsFunc mocks the IndexExists method.
+	IndexExistsFunc func(ctx context.Context, index string) (bool, error)
 
 	// PingFunc mocks the Ping method.
 	PingFunc func(ctx context.Context) error
 
+	// PipelineExistsFunc mocks the PipelineExists method.
+	PipelineExistsFunc func(ctx context.Context, name string) (bool, error)
+
 	// PrepareCreateOperationFunc mocks the PrepareCreateOperation method.
-	PrepareCreateOperationFunc func(item opencdc.Record, index string) (interface{}, interface{}, error)
+	PrepareCreateOperationFunc func(item opencdc.Record, index string, options api.BulkOperationOptions) (interface{}, interface{}, error)
 
 	// PrepareDeleteOperationFunc mocks the PrepareDeleteOperation method.
-	PrepareDeleteOperationFunc func(key string, index string) (interface{}, error)
+	PrepareDeleteOperationFunc func(key string, index string, options api.BulkOperationOptions) (interface{}, error)
+
+	// PrepareDiffUpdateOperationFunc mocks the PrepareDiffUpdateOperation method.
+	PrepareDiffUpdateOperationFunc func(key string, index string, diff api.DocumentDiff, options api.BulkOperationOptions) (interface{}, interface{}, error)
+
+	// PrepareSoftDeleteOperationFunc mocks the PrepareSoftDeleteOperation method.
+	PrepareSoftDeleteOperationFunc func(key string, index string, doc opencdc.StructuredData, options api.BulkOperationOptions) (interface{}, interface{}, error)
 
 	// PrepareUpsertOperationFunc mocks the PrepareUpsertOperation method.
-	PrepareUpsertOperationFunc func(key string, item opencdc.Record, index string) (interface{}, interface{}, error)
+	PrepareUpsertOperationFunc func(key string, item opencdc.Record, index string, options api.BulkOperationOptions) (interface{}, interface{}, error)
+
+	// PutMappingFunc mocks the PutMapping method.
+	PutMappingFunc func(ctx context.Context, index string, mappings json.RawMessage) error
+
+	// PutTemplateFunc mocks the PutTemplate method.
+	PutTemplateFunc func(ctx context.Context, template api.Template) error
 
 	// SearchFunc mocks the Search method.
 	SearchFunc func(ctx context.Context, request *api.SearchRequest) (*api.SearchResponse, error)
 
+	// TemplateExistsFunc mocks the TemplateExists method.
+	TemplateExistsFunc func(ctx context.Context, template api.Template) (bool, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AliasExists holds details about calls to the AliasExists method.
+		AliasExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Alias is the alias argument value.
+			Alias string
+		}
 		// Bulk holds details about calls to the Bulk method.
 		Bulk []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Reader is the reader argument value.
 			Reader io.Reader
+			// Options is the options argument value.
+			Options api.BulkRequestOptions
+		}
+		// CreateIndex holds details about calls to the CreateIndex method.
+		CreateIndex []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Index is the index argument value.
+			Index string
+			// Definition is the definition argument value.
+			Definition api.IndexDefinition
+		}
+		// DeleteByQuery holds details about calls to the DeleteByQuery method.
+		DeleteByQuery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request *api.DeleteByQueryRequest
+		}
+		// IndexExists holds details about calls to the IndexExists method.
+		IndexExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Index is the index argument value.
+			Index string
 		}
 		// Ping holds details about calls to the Ping method.
 		Ping []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// PipelineExists holds details about calls to the PipelineExists method.
+		PipelineExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// PrepareCreateOperation holds details about calls to the PrepareCreateOperation method.
 		PrepareCreateOperation []struct {
 			// Item is the item argument value.
 			Item opencdc.Record
 			// Index is the index argument value.
 			Index string
+			// Options is the options argument value.
+			Options api.BulkOperationOptions
 		}
 		// PrepareDeleteOperation holds details about calls to the PrepareDeleteOperation method.
 		PrepareDeleteOperation []struct {
@@ -91,6 +193,30 @@ type clientMock struct {
 			Key string
 			// Index is the index argument value.
 			Index string
+			// Options is the options argument value.
+			Options api.BulkOperationOptions
+		}
+		// PrepareDiffUpdateOperation holds details about calls to the PrepareDiffUpdateOperation method.
+		PrepareDiffUpdateOperation []struct {
+			// Key is the key argument value.
+			Key string
+			// Index is the index argument value.
+			Index string
+			// Diff is the diff argument value.
+			Diff api.DocumentDiff
+			// Options is the options argument value.
+			Options api.BulkOperationOptions
+		}
+		// PrepareSoftDeleteOperation holds details about calls to the PrepareSoftDeleteOperation method.
+		PrepareSoftDeleteOperation []struct {
+			// Key is the key argument value.
+			Key string
+			// Index is the index argument value.
+			Index string
+			// Doc is the doc argument value.
+			Doc opencdc.StructuredData
+			// Options is the options argument value.
+			Options api.BulkOperationOptions
 		}
 		// PrepareUpsertOperation holds details about calls to the PrepareUpsertOperation method.
 		PrepareUpsertOperation []struct {
@@ -100,6 +226,24 @@ type clientMock struct {
 			Item opencdc.Record
 			// Index is the index argument value.
 			Index string
+			// Options is the options argument value.
+			Options api.BulkOperationOptions
+		}
+		// PutMapping holds details about calls to the PutMapping method.
+		PutMapping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Index is the index argument value.
+			Index string
+			// Mappings is the mappings argument value.
+			Mappings json.RawMessage
+		}
+		// PutTemplate holds details about calls to the PutTemplate method.
+		PutTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Template is the template argument value.
+			Template api.Template
 		}
 		// Search holds details about calls to the Search method.
 		Search []struct {
@@ -108,31 +252,86 @@ type clientMock struct {
 			// Request is the request argument value.
 			Request *api.SearchRequest
 		}
+		// TemplateExists holds details about calls to the TemplateExists method.
+		TemplateExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Template is the template argument value.
+			Template api.Template
+		}
+	}
+	lockAliasExists                sync.RWMutex
+	lockBulk                       sync.RWMutex
+	lockCreateIndex                sync.RWMutex
+	lockDeleteByQuery              sync.RWMutex
+	lockIndexExists                sync.RWMutex
+	lockPing                       sync.RWMutex
+	lockPipelineExists             sync.RWMutex
+	lockPrepareCreateOperation     sync.RWMutex
+	lockPrepareDeleteOperation     sync.RWMutex
+	lockPrepareDiffUpdateOperation sync.RWMutex
+	lockPrepareSoftDeleteOperation sync.RWMutex
+	lockPrepareUpsertOperation     sync.RWMutex
+	lockPutMapping                 sync.RWMutex
+	lockPutTemplate                sync.RWMutex
+	lockSearch                     sync.RWMutex
+	lockTemplateExists             sync.RWMutex
+}
+
+// AliasExists calls AliasExistsFunc.
+func (mock *clientMock) AliasExists(ctx context.Context, alias string) (bool, error) {
+	if mock.AliasExistsFunc == nil {
+		panic("clientMock.AliasExistsFunc: method is nil but client.AliasExists was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Alias string
+	}{
+		Ctx:   ctx,
+		Alias: alias,
+	}
+	mock.lockAliasExists.Lock()
+	mock.calls.AliasExists = append(mock.calls.AliasExists, callInfo)
+	mock.lockAliasExists.Unlock()
+	return mock.AliasExistsFunc(ctx, alias)
+}
+
+// AliasExistsCalls gets all the calls that were made to AliasExists.
+// Check the length with:
+//
+//	len(mockedclient.AliasExistsCalls())
+func (mock *clientMock) AliasExistsCalls() []struct {
+	Ctx   context.Context
+	Alias string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Alias string
 	}
-	lockBulk                   sync.RWMutex
-	lockPing                   sync.RWMutex
-	lockPrepareCreateOperation sync.RWMutex
-	lockPrepareDeleteOperation sync.RWMutex
-	lockPrepareUpsertOperation sync.RWMutex
-	lockSearch                 sync.RWMutex
+	mock.lockAliasExists.RLock()
+	calls = mock.calls.AliasExists
+	mock.lockAliasExists.RUnlock()
+	return calls
 }
 
 // Bulk calls BulkFunc.
-func (mock *clientMock) Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+func (mock *clientMock) Bulk(ctx context.Context, reader io.Reader, options api.BulkRequestOptions) (io.ReadCloser, error) {
 	if mock.BulkFunc == nil {
 		panic("clientMock.BulkFunc: method is nil but client.Bulk was just called")
 	}
 	callInfo := struct {
-		Ctx    context.Context
-		Reader io.Reader
+		Ctx     context.Context
+		Reader  io.Reader
+		Options api.BulkRequestOptions
 	}{
-		Ctx:    ctx,
-		Reader: reader,
+		Ctx:     ctx,
+		Reader:  reader,
+		Options: options,
 	}
 	mock.lockBulk.Lock()
 	mock.calls.Bulk = append(mock.calls.Bulk, callInfo)
 	mock.lockBulk.Unlock()
-	return mock.BulkFunc(ctx, reader)
+	return mock.BulkFunc(ctx, reader, options)
 }
 
 // BulkCalls gets all the calls that were made to Bulk.
@@ -140,12 +339,14 @@ func (mock *clientMock) Bulk(ctx context.Context, reader io.Reader) (io.ReadClos
 //
 //	len(mockedclient.BulkCalls())
 func (mock *clientMock) BulkCalls() []struct {
-	Ctx    context.Context
-	Reader io.Reader
+	Ctx     context.Context
+	Reader  io.Reader
+	Options api.BulkRequestOptions
 } {
 	var calls []struct {
-		Ctx    context.Context
-		Reader io.Reader
+		Ctx     context.Context
+		Reader  io.Reader
+		Options api.BulkRequestOptions
 	}
 	mock.lockBulk.RLock()
 	calls = mock.calls.Bulk
@@ -153,6 +354,118 @@ func (mock *clientMock) BulkCalls() []struct {
 	return calls
 }
 
+// CreateIndex calls CreateIndexFunc.
+func (mock *clientMock) CreateIndex(ctx context.Context, index string, definition api.IndexDefinition) error {
+	if mock.CreateIndexFunc == nil {
+		panic("clientMock.CreateIndexFunc: method is nil but client.CreateIndex was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Index      string
+		Definition api.IndexDefinition
+	}{
+		Ctx:        ctx,
+		Index:      index,
+		Definition: definition,
+	}
+	mock.lockCreateIndex.Lock()
+	mock.calls.CreateIndex = append(mock.calls.CreateIndex, callInfo)
+	mock.lockCreateIndex.Unlock()
+	return mock.CreateIndexFunc(ctx, index, definition)
+}
+
+// CreateIndexCalls gets all the calls that were made to CreateIndex.
+// Check the length with:
+//
+//	len(mockedclient.CreateIndexCalls())
+func (mock *clientMock) CreateIndexCalls() []struct {
+	Ctx        context.Context
+	Index      string
+	Definition api.IndexDefinition
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Index      string
+		Definition api.IndexDefinition
+	}
+	mock.lockCreateIndex.RLock()
+	calls = mock.calls.CreateIndex
+	mock.lockCreateIndex.RUnlock()
+	return calls
+}
+
+// DeleteByQuery calls DeleteByQueryFunc.
+func (mock *clientMock) DeleteByQuery(ctx context.Context, request *api.DeleteByQueryRequest) (int64, error) {
+	if mock.DeleteByQueryFunc == nil {
+		panic("clientMock.DeleteByQueryFunc: method is nil but client.DeleteByQuery was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request *api.DeleteByQueryRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockDeleteByQuery.Lock()
+	mock.calls.DeleteByQuery = append(mock.calls.DeleteByQuery, callInfo)
+	mock.lockDeleteByQuery.Unlock()
+	return mock.DeleteByQueryFunc(ctx, request)
+}
+
+// DeleteByQueryCalls gets all the calls that were made to DeleteByQuery.
+// Check the length with:
+//
+//	len(mockedclient.DeleteByQueryCalls())
+func (mock *clientMock) DeleteByQueryCalls() []struct {
+	Ctx     context.Context
+	Request *api.DeleteByQueryRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request *api.DeleteByQueryRequest
+	}
+	mock.lockDeleteByQuery.RLock()
+	calls = mock.calls.DeleteByQuery
+	mock.lockDeleteByQuery.RUnlock()
+	return calls
+}
+
+// IndexExists calls IndexExistsFunc.
+func (mock *clientMock) IndexExists(ctx context.Context, index string) (bool, error) {
+	if mock.IndexExistsFunc == nil {
+		panic("clientMock.IndexExistsFunc: method is nil but client.IndexExists was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Index string
+	}{
+		Ctx:   ctx,
+		Index: index,
+	}
+	mock.lockIndexExists.Lock()
+	mock.calls.IndexExists = append(mock.calls.IndexExists, callInfo)
+	mock.lockIndexExists.Unlock()
+	return mock.IndexExistsFunc(ctx, index)
+}
+
+// IndexExistsCalls gets all the calls that were made to IndexExists.
+// Check the length with:
+//
+//	len(mockedclient.IndexExistsCalls())
+func (mock *clientMock) IndexExistsCalls() []struct {
+	Ctx   context.Context
+	Index string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Index string
+	}
+	mock.lockIndexExists.RLock()
+	calls = mock.calls.IndexExists
+	mock.lockIndexExists.RUnlock()
+	return calls
+}
+
 // Ping calls PingFunc.
 func (mock *clientMock) Ping(ctx context.Context) error {
 	if mock.PingFunc == nil {
@@ -185,22 +498,60 @@ func (mock *clientMock) PingCalls() []struct {
 	return calls
 }
 
+// PipelineExists calls PipelineExistsFunc.
+func (mock *clientMock) PipelineExists(ctx context.Context, name string) (bool, error) {
+	if mock.PipelineExistsFunc == nil {
+		panic("clientMock.PipelineExistsFunc: method is nil but client.PipelineExists was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockPipelineExists.Lock()
+	mock.calls.PipelineExists = append(mock.calls.PipelineExists, callInfo)
+	mock.lockPipelineExists.Unlock()
+	return mock.PipelineExistsFunc(ctx, name)
+}
+
+// PipelineExistsCalls gets all the calls that were made to PipelineExists.
+// Check the length with:
+//
+//	len(mockedclient.PipelineExistsCalls())
+func (mock *clientMock) PipelineExistsCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockPipelineExists.RLock()
+	calls = mock.calls.PipelineExists
+	mock.lockPipelineExists.RUnlock()
+	return calls
+}
+
 // PrepareCreateOperation calls PrepareCreateOperationFunc.
-func (mock *clientMock) PrepareCreateOperation(item opencdc.Record, index string) (interface{}, interface{}, error) {
+func (mock *clientMock) PrepareCreateOperation(item opencdc.Record, index string, options api.BulkOperationOptions) (interface{}, interface{}, error) {
 	if mock.PrepareCreateOperationFunc == nil {
 		panic("clientMock.PrepareCreateOperationFunc: method is nil but client.PrepareCreateOperation was just called")
 	}
 	callInfo := struct {
-		Item  opencdc.Record
-		Index string
+		Item    opencdc.Record
+		Index   string
+		Options api.BulkOperationOptions
 	}{
-		Item:  item,
-		Index: index,
+		Item:    item,
+		Index:   index,
+		Options: options,
 	}
 	mock.lockPrepareCreateOperation.Lock()
 	mock.calls.PrepareCreateOperation = append(mock.calls.PrepareCreateOperation, callInfo)
 	mock.lockPrepareCreateOperation.Unlock()
-	return mock.PrepareCreateOperationFunc(item, index)
+	return mock.PrepareCreateOperationFunc(item, index, options)
 }
 
 // PrepareCreateOperationCalls gets all the calls that were made to PrepareCreateOperation.
@@ -208,12 +559,14 @@ func (mock *clientMock) PrepareCreateOperation(item opencdc.Record, index string
 //
 //	len(mockedclient.PrepareCreateOperationCalls())
 func (mock *clientMock) PrepareCreateOperationCalls() []struct {
-	Item  opencdc.Record
-	Index string
+	Item    opencdc.Record
+	Index   string
+	Options api.BulkOperationOptions
 } {
 	var calls []struct {
-		Item  opencdc.Record
-		Index string
+		Item    opencdc.Record
+		Index   string
+		Options api.BulkOperationOptions
 	}
 	mock.lockPrepareCreateOperation.RLock()
 	calls = mock.calls.PrepareCreateOperation
@@ -222,21 +575,23 @@ func (mock *clientMock) PrepareCreateOperationCalls() []struct {
 }
 
 // PrepareDeleteOperation calls PrepareDeleteOperationFunc.
-func (mock *clientMock) PrepareDeleteOperation(key string, index string) (interface{}, error) {
+func (mock *clientMock) PrepareDeleteOperation(key string, index string, options api.BulkOperationOptions) (interface{}, error) {
 	if mock.PrepareDeleteOperationFunc == nil {
 		panic("clientMock.PrepareDeleteOperationFunc: method is nil but client.PrepareDeleteOperation was just called")
 	}
 	callInfo := struct {
-		Key   string
-		Index string
+		Key     string
+		Index   string
+		Options api.BulkOperationOptions
 	}{
-		Key:   key,
-		Index: index,
+		Key:     key,
+		Index:   index,
+		Options: options,
 	}
 	mock.lockPrepareDeleteOperation.Lock()
 	mock.calls.PrepareDeleteOperation = append(mock.calls.PrepareDeleteOperation, callInfo)
 	mock.lockPrepareDeleteOperation.Unlock()
-	return mock.PrepareDeleteOperationFunc(key, index)
+	return mock.PrepareDeleteOperationFunc(key, index, options)
 }
 
 // PrepareDeleteOperationCalls gets all the calls that were made to PrepareDeleteOperation.
@@ -244,12 +599,14 @@ func (mock *clientMock) PrepareDeleteOperation(key string, index string) (interf
 //
 //	len(mockedclient.PrepareDeleteOperationCalls())
 func (mock *clientMock) PrepareDeleteOperationCalls() []struct {
-	Key   string
-	Index string
+	Key     string
+	Index   string
+	Options api.BulkOperationOptions
 } {
 	var calls []struct {
-		Key   string
-		Index string
+		Key     string
+		Index   string
+		Options api.BulkOperationOptions
 	}
 	mock.lockPrepareDeleteOperation.RLock()
 	calls = mock.calls.PrepareDeleteOperation
@@ -257,24 +614,114 @@ func (mock *clientMock) PrepareDeleteOperationCalls() []struct {
 	return calls
 }
 
+// PrepareDiffUpdateOperation calls PrepareDiffUpdateOperationFunc.
+func (mock *clientMock) PrepareDiffUpdateOperation(key string, index string, diff api.DocumentDiff, options api.BulkOperationOptions) (interface{}, interface{}, error) {
+	if mock.PrepareDiffUpdateOperationFunc == nil {
+		panic("clientMock.PrepareDiffUpdateOperationFunc: method is nil but client.PrepareDiffUpdateOperation was just called")
+	}
+	callInfo := struct {
+		Key     string
+		Index   string
+		Diff    api.DocumentDiff
+		Options api.BulkOperationOptions
+	}{
+		Key:     key,
+		Index:   index,
+		Diff:    diff,
+		Options: options,
+	}
+	mock.lockPrepareDiffUpdateOperation.Lock()
+	mock.calls.PrepareDiffUpdateOperation = append(mock.calls.PrepareDiffUpdateOperation, callInfo)
+	mock.lockPrepareDiffUpdateOperation.Unlock()
+	return mock.PrepareDiffUpdateOperationFunc(key, index, diff, options)
+}
+
+// PrepareDiffUpdateOperationCalls gets all the calls that were made to PrepareDiffUpdateOperation.
+// Check the length with:
+//
+//	len(mockedclient.PrepareDiffUpdateOperationCalls())
+func (mock *clientMock) PrepareDiffUpdateOperationCalls() []struct {
+	Key     string
+	Index   string
+	Diff    api.DocumentDiff
+	Options api.BulkOperationOptions
+} {
+	var calls []struct {
+		Key     string
+		Index   string
+		Diff    api.DocumentDiff
+		Options api.BulkOperationOptions
+	}
+	mock.lockPrepareDiffUpdateOperation.RLock()
+	calls = mock.calls.PrepareDiffUpdateOperation
+	mock.lockPrepareDiffUpdateOperation.RUnlock()
+	return calls
+}
+
+// PrepareSoftDeleteOperation calls PrepareSoftDeleteOperationFunc.
+func (mock *clientMock) PrepareSoftDeleteOperation(key string, index string, doc opencdc.StructuredData, options api.BulkOperationOptions) (interface{}, interface{}, error) {
+	if mock.PrepareSoftDeleteOperationFunc == nil {
+		panic("clientMock.PrepareSoftDeleteOperationFunc: method is nil but client.PrepareSoftDeleteOperation was just called")
+	}
+	callInfo := struct {
+		Key     string
+		Index   string
+		Doc     opencdc.StructuredData
+		Options api.BulkOperationOptions
+	}{
+		Key:     key,
+		Index:   index,
+		Doc:     doc,
+		Options: options,
+	}
+	mock.lockPrepareSoftDeleteOperation.Lock()
+	mock.calls.PrepareSoftDeleteOperation = append(mock.calls.PrepareSoftDeleteOperation, callInfo)
+	mock.lockPrepareSoftDeleteOperation.Unlock()
+	return mock.PrepareSoftDeleteOperationFunc(key, index, doc, options)
+}
+
+// PrepareSoftDeleteOperationCalls gets all the calls that were made to PrepareSoftDeleteOperation.
+// Check the length with:
+//
+//	len(mockedclient.PrepareSoftDeleteOperationCalls())
+func (mock *clientMock) PrepareSoftDeleteOperationCalls() []struct {
+	Key     string
+	Index   string
+	Doc     opencdc.StructuredData
+	Options api.BulkOperationOptions
+} {
+	var calls []struct {
+		Key     string
+		Index   string
+		Doc     opencdc.StructuredData
+		Options api.BulkOperationOptions
+	}
+	mock.lockPrepareSoftDeleteOperation.RLock()
+	calls = mock.calls.PrepareSoftDeleteOperation
+	mock.lockPrepareSoftDeleteOperation.RUnlock()
+	return calls
+}
+
 // PrepareUpsertOperation calls PrepareUpsertOperationFunc.
-func (mock *clientMock) PrepareUpsertOperation(key string, item opencdc.Record, index string) (interface{}, interface{}, error) {
+func (mock *clientMock) PrepareUpsertOperation(key string, item opencdc.Record, index string, options api.BulkOperationOptions) (interface{}, interface{}, error) {
 	if mock.PrepareUpsertOperationFunc == nil {
 		panic("clientMock.PrepareUpsertOperationFunc: method is nil but client.PrepareUpsertOperation was just called")
 	}
 	callInfo := struct {
-		Key   string
-		Item  opencdc.Record
-		Index string
+		Key     string
+		Item    opencdc.Record
+		Index   string
+		Options api.BulkOperationOptions
 	}{
-		Key:   key,
-		Item:  item,
-		Index: index,
+		Key:     key,
+		Item:    item,
+		Index:   index,
+		Options: options,
 	}
 	mock.lockPrepareUpsertOperation.Lock()
 	mock.calls.PrepareUpsertOperation = append(mock.calls.PrepareUpsertOperation, callInfo)
 	mock.lockPrepareUpsertOperation.Unlock()
-	return mock.PrepareUpsertOperationFunc(key, item, index)
+	return mock.PrepareUpsertOperationFunc(key, item, index, options)
 }
 
 // PrepareUpsertOperationCalls gets all the calls that were made to PrepareUpsertOperation.
@@ -282,14 +729,16 @@ func (mock *clientMock) PrepareUpsertOperation(key string, item opencdc.Record,
 //
 //	len(mockedclient.PrepareUpsertOperationCalls())
 func (mock *clientMock) PrepareUpsertOperationCalls() []struct {
-	Key   string
-	Item  opencdc.Record
-	Index string
+	Key     string
+	Item    opencdc.Record
+	Index   string
+	Options api.BulkOperationOptions
 } {
 	var calls []struct {
-		Key   string
-		Item  opencdc.Record
-		Index string
+		Key     string
+		Item    opencdc.Record
+		Index   string
+		Options api.BulkOperationOptions
 	}
 	mock.lockPrepareUpsertOperation.RLock()
 	calls = mock.calls.PrepareUpsertOperation
@@ -297,6 +746,82 @@ func (mock *clientMock) PrepareUpsertOperationCalls() []struct {
 	return calls
 }
 
+// PutMapping calls PutMappingFunc.
+func (mock *clientMock) PutMapping(ctx context.Context, index string, mappings json.RawMessage) error {
+	if mock.PutMappingFunc == nil {
+		panic("clientMock.PutMappingFunc: method is nil but client.PutMapping was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Index    string
+		Mappings json.RawMessage
+	}{
+		Ctx:      ctx,
+		Index:    index,
+		Mappings: mappings,
+	}
+	mock.lockPutMapping.Lock()
+	mock.calls.PutMapping = append(mock.calls.PutMapping, callInfo)
+	mock.lockPutMapping.Unlock()
+	return mock.PutMappingFunc(ctx, index, mappings)
+}
+
+// PutMappingCalls gets all the calls that were made to PutMapping.
+// Check the length with:
+//
+//	len(mockedclient.PutMappingCalls())
+func (mock *clientMock) PutMappingCalls() []struct {
+	Ctx      context.Context
+	Index    string
+	Mappings json.RawMessage
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Index    string
+		Mappings json.RawMessage
+	}
+	mock.lockPutMapping.RLock()
+	calls = mock.calls.PutMapping
+	mock.lockPutMapping.RUnlock()
+	return calls
+}
+
+// PutTemplate calls PutTemplateFunc.
+func (mock *clientMock) PutTemplate(ctx context.Context, template api.Template) error {
+	if mock.PutTemplateFunc == nil {
+		panic("clientMock.PutTemplateFunc: method is nil but client.PutTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Template api.Template
+	}{
+		Ctx:      ctx,
+		Template: template,
+	}
+	mock.lockPutTemplate.Lock()
+	mock.calls.PutTemplate = append(mock.calls.PutTemplate, callInfo)
+	mock.lockPutTemplate.Unlock()
+	return mock.PutTemplateFunc(ctx, template)
+}
+
+// PutTemplateCalls gets all the calls that were made to PutTemplate.
+// Check the length with:
+//
+//	len(mockedclient.PutTemplateCalls())
+func (mock *clientMock) PutTemplateCalls() []struct {
+	Ctx      context.Context
+	Template api.Template
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Template api.Template
+	}
+	mock.lockPutTemplate.RLock()
+	calls = mock.calls.PutTemplate
+	mock.lockPutTemplate.RUnlock()
+	return calls
+}
+
 // Search calls SearchFunc.
 func (mock *clientMock) Search(ctx context.Context, request *api.SearchRequest) (*api.SearchResponse, error) {
 	if mock.SearchFunc == nil {
@@ -332,3 +857,39 @@ func (mock *clientMock) SearchCalls() []struct {
 	mock.lockSearch.RUnlock()
 	return calls
 }
+
+// TemplateExists calls TemplateExistsFunc.
+func (mock *clientMock) TemplateExists(ctx context.Context, template api.Template) (bool, error) {
+	if mock.TemplateExistsFunc == nil {
+		panic("clientMock.TemplateExistsFunc: method is nil but client.TemplateExists was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Template api.Template
+	}{
+		Ctx:      ctx,
+		Template: template,
+	}
+	mock.lockTemplateExists.Lock()
+	mock.calls.TemplateExists = append(mock.calls.TemplateExists, callInfo)
+	mock.lockTemplateExists.Unlock()
+	return mock.TemplateExistsFunc(ctx, template)
+}
+
+// TemplateExistsCalls gets all the calls that were made to TemplateExists.
+// Check the length with:
+//
+//	len(mockedclient.TemplateExistsCalls())
+func (mock *clientMock) TemplateExistsCalls() []struct {
+	Ctx      context.Context
+	Template api.Template
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Template api.Template
+	}
+	mock.lockTemplateExists.RLock()
+	calls = mock.calls.TemplateExists
+	mock.lockTemplateExists.RUnlock()
+	return calls
+}
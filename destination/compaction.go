@@ -0,0 +1,111 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"slices"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// operationEffect is the effect of an operation on the Document, deciding whether it supersedes earlier operations.
+type operationEffect int
+
+const (
+	// effectPartial depends on or merges into the current state of the Document, so the earlier operations are kept.
+	effectPartial operationEffect = iota
+	// effectReplace determines the whole state of the Document, superseding any earlier operation.
+	effectReplace
+)
+
+// operationEffect returns the effect of the record's operation on the targeted Document.
+func (d *Destination) operationEffect(record opencdc.Record) operationEffect {
+	// Appends are never superseded, and the latest record isn't necessarily the one with the newest version
+	if d.config.DataStream || d.getVersion != nil {
+		return effectPartial
+	}
+
+	switch record.Operation {
+	case opencdc.OperationDelete:
+		switch d.config.DeletePolicy {
+		case DeletePolicySoft, DeletePolicyIgnore:
+			return effectPartial
+		default:
+			return effectReplace
+		}
+
+	case opencdc.OperationCreate, opencdc.OperationSnapshot, opencdc.OperationUpdate:
+		if record.Operation == opencdc.OperationUpdate && d.diffsUpdates() {
+			return effectPartial
+		}
+
+		// Updates merge the payload into the Document, so the fields written only by the earlier updates are kept
+		if d.config.GetWriteMode() == api.WriteModeIndex {
+			return effectReplace
+		}
+	}
+
+	return effectPartial
+}
+
+// compactRecords reports which records have operations superseded by later operations on the same Document,
+// along with the records superseded by each of the remaining operations, in their order in the batch.
+// Operations are not compacted across deletes by query, as those might match any Document.
+func (d *Destination) compactRecords(ctx context.Context, records []opencdc.Record, targets []bulkTarget) ([]bool, [][]int) {
+	compacted := make([]bool, len(records))
+	superseded := make([][]int, len(records))
+
+	// later is the latest record operating on each Document
+	later := make(map[string]int)
+
+	var count int
+	for i := len(records) - 1; i >= 0; i-- {
+		if targets[i].key == "" {
+			if records[i].Operation == opencdc.OperationDelete && !d.config.DataStream {
+				clear(later)
+			}
+
+			continue
+		}
+
+		docKey := documentKey(targets[i].index, targets[i].key)
+
+		if j, ok := later[docKey]; ok && d.operationEffect(records[j]) == effectReplace {
+			compacted[i] = true
+			superseded[j] = append(superseded[j], i)
+			count++
+
+			continue
+		}
+
+		later[docKey] = i
+	}
+
+	if count > 0 {
+		sdk.Logger(ctx).Debug().
+			Int("records", count).
+			Msg("operations superseded by later operations on the same documents, skipping")
+	}
+
+	// The superseded records were collected starting from the last one
+	for _, s := range superseded {
+		slices.Reverse(s)
+	}
+
+	return compacted, superseded
+}
@@ -18,17 +18,31 @@ package destination
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/opencdc"
 )
 
 type IndexFn func(opencdc.Record) (string, error)
 
+type VersionFn func(opencdc.Record) (int64, error)
+
+type RoutingFn func(opencdc.Record) (string, error)
+
+type PipelineFn func(opencdc.Record) (string, error)
+
+type ScriptParamsFn func(opencdc.Record) (map[string]json.RawMessage, error)
+
 type Config struct {
 	// The version of the Elasticsearch service. One of: 5, 6, 7, 8.
 	Version elasticsearch.Version `json:"version" validate:"required"`
@@ -46,14 +60,130 @@ type Config struct {
 	ServiceToken string `json:"serviceToken"`
 	// SHA256 hex fingerprint given by Elasticsearch on first launch.
 	CertificateFingerprint string `json:"certificateFingerprint"`
+	// Whether the request bodies are compressed with gzip.
+	CompressRequestBody bool `json:"compressRequestBody"`
+	// The gzip compression level of the request bodies, from `1` (best speed) to `9` (best compression). If `0`, the default level is used.
+	CompressionLevel int `json:"compressionLevel" validate:"gt=-1,lt=10"`
 	// The name of the index to write the data to.
 	Index string `json:"index" default:"{{ index .Metadata \"opencdc.collection\" }}"`
+	// The Document ID. It can contain a Go template that will be executed for each record to determine the ID. If empty, the ID is derived from the record's key according to `keyFormat`.
+	IDTemplate string `json:"idTemplate"`
+	// The format of the Document ID derived from a structured key. One of: `json` (the key encoded as JSON), `join` (the values of the key fields sorted by their names, joined with `keySeparator`) or `hash` (the hex encoded SHA-256 hash of the key).
+	KeyFormat string `json:"keyFormat" default:"json" validate:"inclusion=json|join|hash"`
+	// The separator of the key field values used by the `join` key format.
+	KeySeparator string `json:"keySeparator" default:"_"`
+	// The strategy of deriving the Document ID of records without a key, so replayed records overwrite the same Document. One of: `none` (the ID is generated by Elasticsearch), `position` (the hash of the record's collection and position) or `payload` (the hash of the payload fields listed in `keylessIDFields`, or of the whole payload).
+	KeylessID string `json:"keylessID" default:"none" validate:"inclusion=none|position|payload"`
+	// The payload fields used to derive the Document ID by the `payload` keyless ID strategy.
+	KeylessIDFields []string `json:"keylessIDFields"`
 	// The name of the index's type to write the data to.
 	Type string `json:"type"`
+	// The mode of writing Documents with an ID. One of: `update` (merges the Document, inserts it when missing), `index` (replaces the whole Document), `create` (skips Documents that already exist) or `script` (updates the Document with the script, inserts it when missing). In the `script` mode, the operations on the same Document are sent in separate bulk requests, so a retried script is neither reordered nor applied twice.
+	WriteMode api.WriteMode `json:"writeMode" default:"update" validate:"inclusion=update|index|create|script"`
+	// The number of times an update is retried on a version conflict. Used by the `update` and `script` write modes.
+	RetryOnConflict int `json:"retryOnConflict" default:"3" validate:"gt=-1"`
+	// The Painless script source used by the `script` write mode. The Document is available as `params.doc`.
+	Script string `json:"script"`
+	// The ID of the stored script used by the `script` write mode instead of `script`.
+	ScriptID string `json:"scriptID"`
+	// The params of the script used by the `script` write mode. It's a Go template executed for each record that must result in a JSON object, e.g. `{"count": {{ index .Payload.After "count" }}}`. If empty, the Document is passed as `params.doc`.
+	ScriptParams string `json:"scriptParams"`
+	// The mode of inserting missing Documents by the `script` write mode. One of: `document` (inserts the record's payload), `scripted` (runs the script against an empty Document) or `none` (rejects the update, handled by `errorPolicy`).
+	ScriptUpsert string `json:"scriptUpsert" default:"document" validate:"inclusion=document|scripted|none"`
+	// Whether updates with a structured `before` and `after` payload send only the fields changed between them instead of the whole Document. Requires the `update` write mode.
+	UpdateDiff bool `json:"updateDiff"`
+	// The way of handling fields removed by an update in the `updateDiff` mode. One of: `remove` (removes the fields from the Document with a Painless script) or `null` (sets the fields to `null`).
+	UpdateDiffRemovedFields string `json:"updateDiffRemovedFields" default:"remove" validate:"inclusion=remove|null"`
+	// The policy of handling deletes. One of: `hard` (deletes the Document), `soft` (marks the Document as deleted with `softDeleteField` and `softDeleteTimeField`) or `ignore` (leaves the Document untouched). Deletes written to a data stream are handled by `dataStreamDeletePolicy`.
+	DeletePolicy string `json:"deletePolicy" default:"hard" validate:"inclusion=hard|soft|ignore"`
+	// The field set to `true` in Documents deleted by the `soft` delete policy.
+	SoftDeleteField string `json:"softDeleteField" default:"deleted"`
+	// The field set to the time of the deletion in Documents deleted by the `soft` delete policy, taken from the `opencdc.createdAt` or `opencdc.readAt` metadata. If empty, the time is not set.
+	SoftDeleteTimeField string `json:"softDeleteTimeField" default:"deleted_at"`
+	// The payload fields matched by the delete by query issued for deletes without a key. Their values are taken from the record's `before` payload and matched exactly, so string fields must be mapped as `keyword`, not as analyzed `text`. Only Documents visible to search are matched, so Documents written earlier in the same batch are matched only with `refresh` set to `true` or `wait_for`. If empty, deletes without a key fail.
+	DeleteByQueryFields []string `json:"deleteByQueryFields"`
+	// The payload fields kept in the Documents, all other fields are dropped. If empty, all fields are kept. Nested fields are referred to by their dotted names when `flattenFields` is enabled.
+	IncludeFields []string `json:"includeFields"`
+	// The payload fields dropped from the Documents.
+	ExcludeFields []string `json:"excludeFields"`
+	// The payload fields renamed in the Documents, as `from:to` pairs.
+	RenameFields []string `json:"renameFields"`
+	// Whether nested objects of the payload are flattened to fields with dotted names, e.g. `address.city`.
+	FlattenFields bool `json:"flattenFields"`
+	// Whether null fields of the payload are dropped from the Documents.
+	DropNullFields bool `json:"dropNullFields"`
+	// The record fields injected into the Documents. Any of: `collection`, `position`, `operation`, `createdAt` or `readAt`.
+	MetadataFields []string `json:"metadataFields"`
+	// The prefix of the names of the record fields injected into the Documents.
+	MetadataFieldsPrefix string `json:"metadataFieldsPrefix" default:"_conduit_"`
+	// The policy of handling records rejected by Elasticsearch, e.g. because of a mapping error. One of: `fail` (stops writing), `skip` (logs the rejected records and continues) or `deadLetterIndex` (writes the rejected records along with the errors to `deadLetterIndex` and continues). Records failing with a retryable status (429, 503 or 409) are not rejected, the write fails once `retries` are exhausted, so they are redelivered.
+	ErrorPolicy string `json:"errorPolicy" default:"fail" validate:"inclusion=fail|skip|deadLetterIndex"`
+	// The name of the index the rejected records are written to by the `deadLetterIndex` error policy.
+	DeadLetterIndex string `json:"deadLetterIndex"`
+	// The external version of the Document. It's a Go template executed for each record that must result in a non-negative integer, e.g. `{{ index .Metadata "opencdc.readAt" }}`. Documents are written only when the version is newer than the stored one, stale operations are skipped. Requires the `index` write mode.
+	VersionTemplate string `json:"versionTemplate"`
+	// The type of the external version. One of: `external` (the version must be greater than the stored one) or `external_gte` (the version must be greater than or equal to the stored one).
+	VersionType string `json:"versionType" default:"external" validate:"inclusion=external|external_gte"`
+	// The custom routing of the Document. It's a Go template executed for each record, e.g. `{{ .Key.tenant }}`. Deletes are routed using the record's `before` payload in place of the missing `after` payload, so they reach the same shard as the Document.
+	Routing string `json:"routing"`
+	// The name of the ingest pipeline the Documents are processed by. It can contain a Go template that will be executed for each record to determine the pipeline. A static pipeline is checked to exist when the connector is opened. Requires the `index` or `create` write mode, or a data stream, as partial updates are not processed by pipelines.
+	Pipeline string `json:"pipeline"`
+	// Whether the index is a data stream, supported by Elasticsearch 7.9 and later. Documents are only appended with the `create` operation, `writeMode` is ignored.
+	DataStream bool `json:"dataStream"`
+	// The payload field the `@timestamp` of the Documents written to a data stream is copied from. If empty, the `@timestamp` payload field is kept, or set from `dataStreamTimestampMetadata`.
+	DataStreamTimestampField string `json:"dataStreamTimestampField"`
+	// The metadata field the `@timestamp` of the Documents written to a data stream is set from when the payload has none. One of: `opencdc.readAt` or `opencdc.createdAt`.
+	DataStreamTimestampMetadata string `json:"dataStreamTimestampMetadata" default:"opencdc.readAt" validate:"inclusion=opencdc.readAt|opencdc.createdAt"`
+	// The policy of handling updates written to a data stream. One of: `append` (appends the record as a new Document), `skip` (logs the record and continues), `fail` (stops writing) or `deadLetterIndex` (writes the record to `deadLetterIndex`).
+	DataStreamUpdatePolicy string `json:"dataStreamUpdatePolicy" default:"append" validate:"inclusion=append|skip|fail|deadLetterIndex"`
+	// The policy of handling deletes written to a data stream. One of: `skip` (logs the record and continues), `fail` (stops writing) or `deadLetterIndex` (writes the record to `deadLetterIndex`).
+	DataStreamDeletePolicy string `json:"dataStreamDeletePolicy" default:"fail" validate:"inclusion=skip|fail|deadLetterIndex"`
+	// The mappings of the indices created by the connector, either inline JSON or a path to a JSON file. Indices are created when missing unless `indexTemplate` is set.
+	IndexMappings string `json:"indexMappings"`
+	// The settings of the indices created by the connector, either inline JSON or a path to a JSON file. Indices are created when missing unless `indexTemplate` is set.
+	IndexSettings string `json:"indexSettings"`
+	// The name of the template holding `indexMappings` and `indexSettings`. The template is created when missing, instead of creating the indices.
+	IndexTemplate string `json:"indexTemplate"`
+	// The type of `indexTemplate`. One of: `index` (applied to the indices matching `indexTemplatePatterns`) or `component` (composed into index templates). With `version` 7 or 8 both are composable templates, which require Elasticsearch 7.8 or later. With `version` 5 or 6 `index` is a legacy template.
+	IndexTemplateType string `json:"indexTemplateType" default:"index" validate:"inclusion=index|component"`
+	// The patterns of the index names the `index` template is applied to.
+	IndexTemplatePatterns []string `json:"indexTemplatePatterns"`
+	// Whether the index mappings are derived from the Avro payload schemas of the records, fetched from the schema service. The index is created, or its mappings extended, before the first record of each schema version is written to it. Other schema types are skipped with a warning, their fields are mapped dynamically.
+	SchemaMappings bool `json:"schemaMappings"`
+	// The Elasticsearch type of the string fields of the payload schemas. One of: `keyword` or `text`.
+	SchemaStringType string `json:"schemaStringType" default:"keyword" validate:"inclusion=keyword|text"`
+	// Whether the index names are rollover aliases of indices managed by ILM. A missing alias is bootstrapped with the initial `<alias>-000001` index as its write index. Supported by Elasticsearch 6.6 and later.
+	Rollover bool `json:"rollover"`
+	// The name of the ILM policy managing the indices bootstrapped by the `rollover` mode. The bootstrapped indices are rolled over by the policy using the alias.
+	ILMPolicy string `json:"ilmPolicy"`
 	// The number of items stored in bulk in the index. The minimum value is `1`, maximum value is `10 000`.
-	BulkSize uint64 `json:"bulkSize" default:"1000"`
-	// The maximum number of retries of failed operations. The minimum value is `0` which disabled retry logic. The maximum value is `255.
+	BulkSize uint64 `json:"bulkSize" default:"1000" validate:"gt=0,lt=10001"`
+	// The maximum size of a single bulk request body in bytes. Records exceeding the limit are sent in subsequent requests.
+	BulkMaxBytes uint64 `json:"bulkMaxBytes" default:"10485760"`
+	// Whether the number of items per bulk request adapts to the backpressure of the cluster between `adaptiveBulkMinSize` and `bulkSize`. The size is halved and the requests are paced when items are rejected with 429 or `es_rejected_execution_exception`, it shrinks when the requests are slower than `adaptiveBulkTargetLatency` and grows back otherwise.
+	AdaptiveBulkSize bool `json:"adaptiveBulkSize"`
+	// The minimum number of items per bulk request in the `adaptiveBulkSize` mode.
+	AdaptiveBulkMinSize uint64 `json:"adaptiveBulkMinSize" default:"10" validate:"gt=0,lt=10001"`
+	// The bulk request latency above which the `adaptiveBulkSize` mode shrinks the requests.
+	AdaptiveBulkTargetLatency time.Duration `json:"adaptiveBulkTargetLatency" default:"2s"`
+	// The maximum pause between bulk requests in the `adaptiveBulkSize` mode.
+	AdaptiveBulkMaxDelay time.Duration `json:"adaptiveBulkMaxDelay" default:"5s"`
+	// Whether the earlier operations on the same Document in a batch are skipped when a later one replaces the whole Document. Only a hard delete or a write in the `index` mode supersedes the earlier operations, writes in the `update` mode merge into the Document and are kept. The superseded records are reported as written along with the superseding one and are handled by `errorPolicy` along with it when it's rejected. Scripted, diff, soft delete and versioned operations and data streams don't supersede other operations.
+	CompactOperations bool `json:"compactOperations"`
+	// The number of bulk requests sent concurrently. The records are partitioned among the workers by their Document IDs, so the operations on the same Document are written in order.
+	BulkWorkers int `json:"bulkWorkers" default:"1" validate:"gt=0"`
+	// The refresh policy of the bulk requests. One of: `false` (the changes become visible to search with the periodic refresh), `true` (refreshes the affected shards immediately) or `wait_for` (waits for the changes to become visible to search before the records are acknowledged).
+	Refresh string `json:"refresh" default:"false" validate:"inclusion=false|true|wait_for"`
+	// The number of shard copies that must be active before the bulk requests proceed, either a positive number or `all`. If empty, the Elasticsearch default is used.
+	WaitForActiveShards string `json:"waitForActiveShards"`
+	// The time the bulk requests wait for the active shards. If zero, the Elasticsearch default is used.
+	BulkTimeout time.Duration `json:"bulkTimeout"`
+	// The maximum number of retries of failed operations. The minimum value is `0` which disabled retry logic. The maximum value is `255`. Only the items rejected with a retryable status (429, 503 or 409) are sent again, as well as whole bulk requests rejected with 429 or 503.
 	Retries uint8 `json:"retries" default:"0"`
+	// The initial delay before retrying failed operations. The delay grows exponentially with every retry.
+	RetryMinDelay time.Duration `json:"retryMinDelay" default:"100ms"`
+	// The maximum delay between retries of failed operations.
+	RetryMaxDelay time.Duration `json:"retryMaxDelay" default:"10s"`
 }
 
 func (c Config) GetHost() string {
@@ -84,34 +214,364 @@ func (c Config) GetCertificateFingerprint() string {
 	return c.CertificateFingerprint
 }
 
+func (c Config) GetCompressRequestBody() bool {
+	return c.CompressRequestBody
+}
+
+func (c Config) GetCompressionLevel() int {
+	return c.CompressionLevel
+}
+
 func (c Config) GetType() string {
 	return c.Type
 }
 
+func (c Config) GetWriteMode() string {
+	// Data streams accept only appended Documents
+	if c.DataStream {
+		return api.WriteModeCreate
+	}
+
+	return c.WriteMode
+}
+
+func (c Config) GetRetryOnConflict() int {
+	return c.RetryOnConflict
+}
+
+func (c Config) GetScript() string {
+	return c.Script
+}
+
+func (c Config) GetScriptID() string {
+	return c.ScriptID
+}
+
+func (c Config) GetScriptUpsert() string {
+	return c.ScriptUpsert
+}
+
+// Validate checks whether the options are consistent with each other.
+func (c Config) Validate() error {
+	if c.WriteMode == api.WriteModeScript && c.Script == "" && c.ScriptID == "" {
+		return fmt.Errorf("%q or %q is required when %q is %q", ConfigScript, ConfigScriptID, ConfigWriteMode, api.WriteModeScript)
+	}
+
+	if c.Script != "" && c.ScriptID != "" {
+		return fmt.Errorf("%q is not supported when %q is set", ConfigScriptID, ConfigScript)
+	}
+
+	if c.VersionTemplate != "" && c.WriteMode != api.WriteModeIndex {
+		return fmt.Errorf("%q requires %q to be %q", ConfigVersionTemplate, ConfigWriteMode, api.WriteModeIndex)
+	}
+
+	if c.WaitForActiveShards != "" && c.WaitForActiveShards != "all" {
+		if shards, err := strconv.Atoi(c.WaitForActiveShards); err != nil || shards < 1 {
+			return fmt.Errorf("%q must be a positive number or %q", ConfigWaitForActiveShards, "all")
+		}
+	}
+
+	if c.AdaptiveBulkSize {
+		if c.AdaptiveBulkMinSize > c.BulkSize {
+			return fmt.Errorf("%q must not be greater than %q", ConfigAdaptiveBulkMinSize, ConfigBulkSize)
+		}
+
+		if c.AdaptiveBulkTargetLatency <= 0 {
+			return fmt.Errorf("%q must be positive when %q is enabled", ConfigAdaptiveBulkTargetLatency, ConfigAdaptiveBulkSize)
+		}
+	}
+
+	// Ingest pipelines process only the Documents that are created or replaced
+	if c.Pipeline != "" && c.GetWriteMode() != api.WriteModeIndex && c.GetWriteMode() != api.WriteModeCreate {
+		return fmt.Errorf("%q requires %q to be %q or %q, or %q to be enabled",
+			ConfigPipeline, ConfigWriteMode, api.WriteModeIndex, api.WriteModeCreate, ConfigDataStream)
+	}
+
+	if c.UpdateDiff && c.WriteMode != api.WriteModeUpdate {
+		return fmt.Errorf("%q requires %q to be %q", ConfigUpdateDiff, ConfigWriteMode, api.WriteModeUpdate)
+	}
+
+	if _, err := c.renames(); err != nil {
+		return fmt.Errorf("invalid %q: %w", ConfigRenameFields, err)
+	}
+
+	if c.SchemaMappings && c.transformsDocuments() {
+		return fmt.Errorf("%q is not supported when the Documents are transformed", ConfigSchemaMappings)
+	}
+
+	if c.DeletePolicy == DeletePolicySoft && c.SoftDeleteField == "" {
+		return fmt.Errorf("%q is required when %q is %q", ConfigSoftDeleteField, ConfigDeletePolicy, DeletePolicySoft)
+	}
+
+	if c.DeletePolicy == DeletePolicySoft && c.VersionTemplate != "" {
+		return fmt.Errorf("%q is not supported when %q is %q", ConfigVersionTemplate, ConfigDeletePolicy, DeletePolicySoft)
+	}
+
+	if c.ErrorPolicy == ErrorPolicyDeadLetterIndex && c.DeadLetterIndex == "" {
+		return fmt.Errorf("%q is required when %q is %q", ConfigDeadLetterIndex, ConfigErrorPolicy, ErrorPolicyDeadLetterIndex)
+	}
+
+	if c.IndexTemplate != "" && c.IndexTemplateType == api.TemplateTypeComponent &&
+		c.Version != elasticsearch.Version7 && c.Version != elasticsearch.Version8 {
+		return fmt.Errorf("%q %q requires %q to be %q or %q",
+			ConfigIndexTemplateType, api.TemplateTypeComponent, ConfigVersion, elasticsearch.Version7, elasticsearch.Version8)
+	}
+
+	if c.IndexTemplate != "" && c.IndexTemplateType != api.TemplateTypeComponent && len(c.IndexTemplatePatterns) == 0 {
+		return fmt.Errorf("%q is required when %q is set", ConfigIndexTemplatePatterns, ConfigIndexTemplate)
+	}
+
+	if c.Rollover {
+		if c.Version == elasticsearch.Version5 {
+			return fmt.Errorf("%q is not supported when %q is %q", ConfigRollover, ConfigVersion, elasticsearch.Version5)
+		}
+
+		if c.DataStream {
+			return fmt.Errorf("%q is not supported when %q is enabled", ConfigRollover, ConfigDataStream)
+		}
+
+		// The bootstrapped indices are never rolled over without a policy
+		if c.ILMPolicy == "" {
+			return fmt.Errorf("%q is required when %q is enabled", ConfigIlmPolicy, ConfigRollover)
+		}
+	}
+
+	if c.DataStream {
+		if c.Version != elasticsearch.Version7 && c.Version != elasticsearch.Version8 {
+			return fmt.Errorf("%q requires %q to be %q or %q", ConfigDataStream, ConfigVersion, elasticsearch.Version7, elasticsearch.Version8)
+		}
+
+		if c.VersionTemplate != "" {
+			return fmt.Errorf("%q is not supported when %q is enabled", ConfigVersionTemplate, ConfigDataStream)
+		}
+
+		if c.SchemaMappings {
+			return fmt.Errorf("%q is not supported when %q is enabled", ConfigSchemaMappings, ConfigDataStream)
+		}
+
+		if c.IndexTemplate == "" && (c.IndexMappings != "" || c.IndexSettings != "") {
+			return fmt.Errorf("%q is required to define the indices when %q is enabled", ConfigIndexTemplate, ConfigDataStream)
+		}
+
+		if c.DeadLetterIndex == "" {
+			for name, policy := range map[string]string{
+				ConfigDataStreamUpdatePolicy: c.DataStreamUpdatePolicy,
+				ConfigDataStreamDeletePolicy: c.DataStreamDeletePolicy,
+			} {
+				if policy == DataStreamPolicyDeadLetterIndex {
+					return fmt.Errorf("%q is required when %q is %q", ConfigDeadLetterIndex, name, DataStreamPolicyDeadLetterIndex)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// DocumentIDFunction returns a function that determines the Document ID for each record individually.
+// If the ID template is not set, the ID is derived from the record's key.
+// Records without a key get an ID derived according to the keyless ID strategy.
+// If the ID template is not a valid template, an error is returned.
+func (c Config) DocumentIDFunction() (IDFn, error) {
+	idFn, err := c.keyIDFunction()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.KeylessID == "" || c.KeylessID == KeylessIDNone {
+		return idFn, nil
+	}
+
+	// Fall back to an ID derived from the record itself when there is no key
+	keylessIDFn := keylessDocumentID(c.KeylessID, c.KeylessIDFields)
+
+	return func(r opencdc.Record) (string, error) {
+		id, err := idFn(r)
+		if err != nil || id != "" {
+			return id, err
+		}
+
+		return keylessIDFn(r)
+	}, nil
+}
+
+// keyIDFunction returns a function that determines the Document ID from the ID template or the record's key.
+func (c Config) keyIDFunction() (IDFn, error) {
+	if c.IDTemplate == "" {
+		return keyDocumentID(c.KeyFormat, c.KeySeparator), nil
+	}
+
+	idFn, err := recordTemplate("ID", c.IDTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("ID template is not a valid Go template: %w", err)
+	}
+
+	return idFn, nil
+}
+
+// VersionFunction returns a function that determines the external Document version for each record individually.
+// If the version template is not a valid template, an error is returned.
+func (c Config) VersionFunction() (VersionFn, error) {
+	versionFn, err := recordTemplate("version", c.VersionTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("version template is not a valid Go template: %w", err)
+	}
+
+	return func(r opencdc.Record) (int64, error) {
+		value, err := versionFn(r)
+		if err != nil {
+			return 0, err
+		}
+
+		version, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || version < 0 {
+			return 0, fmt.Errorf("version %q is not a non-negative integer", value)
+		}
+
+		return version, nil
+	}, nil
+}
+
+// RoutingFunction returns a function that determines the routing of the Document for each record individually.
+// Deletes without the `after` payload are evaluated against the `before` payload, so they are routed like the Document.
+// If the routing template is not a valid template, an error is returned.
+func (c Config) RoutingFunction() (RoutingFn, error) {
+	routingFn, err := recordTemplate("routing", c.Routing)
+	if err != nil {
+		return nil, fmt.Errorf("routing template is not a valid Go template: %w", err)
+	}
+
+	return func(r opencdc.Record) (string, error) {
+		if r.Operation == opencdc.OperationDelete && r.Payload.After == nil {
+			r.Payload.After = r.Payload.Before
+		}
+
+		return routingFn(r)
+	}, nil
+}
+
+// IndexDefinition returns the mappings and settings of the indices created by the connector.
+// Each of them is either inline JSON or a path to a JSON file.
+func (c Config) IndexDefinition() (api.IndexDefinition, error) {
+	mappings, err := loadJSON(c.IndexMappings)
+	if err != nil {
+		return api.IndexDefinition{}, fmt.Errorf("invalid index mappings: %w", err)
+	}
+
+	settings, err := loadJSON(c.IndexSettings)
+	if err != nil {
+		return api.IndexDefinition{}, fmt.Errorf("invalid index settings: %w", err)
+	}
+
+	return api.IndexDefinition{
+		Mappings: mappings,
+		Settings: settings,
+	}, nil
+}
+
+// loadJSON returns the inline JSON object or reads it from the file.
+func loadJSON(value string) (json.RawMessage, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	data := []byte(value)
+	if !strings.HasPrefix(value, "{") {
+		var err error
+		if data, err = os.ReadFile(value); err != nil {
+			return nil, err
+		}
+	}
+
+	if !json.Valid(data) {
+		return nil, errors.New("not a valid JSON")
+	}
+
+	return data, nil
+}
+
 // IndexFunction returns a function that determines the index for each record individually.
 // The function might be returning a static index name.
 // If the index is neither static nor a template, an error is returned.
 func (c Config) IndexFunction() (f IndexFn, err error) {
 	// Not a template, i.e. it's a static index name
-	if !strings.Contains(c.Index, "{{") && !strings.Contains(c.Index, "}}") {
+	if !isTemplate(c.Index) {
 		return func(_ opencdc.Record) (string, error) {
 			return c.Index, nil
 		}, nil
 	}
 
 	// Try to parse the index
-	t, err := template.New("index").Funcs(sprig.FuncMap()).Parse(c.Index)
+	indexFn, err := recordTemplate("index", c.Index)
 	if err != nil {
 		// The index is not a valid Go template.
 		return nil, fmt.Errorf("index is neither a valid static index nor a valid Go template: %w", err)
 	}
 
 	// The index is a valid template, return IndexFn.
+	return indexFn, nil
+}
+
+// PipelineFunction returns a function that determines the ingest pipeline for each record individually.
+// The function might be returning a static pipeline name.
+// If the pipeline is neither static nor a template, an error is returned.
+func (c Config) PipelineFunction() (PipelineFn, error) {
+	// Not a template, i.e. it's a static pipeline name
+	if !isTemplate(c.Pipeline) {
+		return func(_ opencdc.Record) (string, error) {
+			return c.Pipeline, nil
+		}, nil
+	}
+
+	pipelineFn, err := recordTemplate("pipeline", c.Pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline is neither a valid static pipeline nor a valid Go template: %w", err)
+	}
+
+	return pipelineFn, nil
+}
+
+// ScriptParamsFunction returns a function that determines the script params for each record individually.
+// If the script params are not a valid template, an error is returned.
+func (c Config) ScriptParamsFunction() (ScriptParamsFn, error) {
+	paramsFn, err := recordTemplate("scriptParams", c.ScriptParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(r opencdc.Record) (map[string]json.RawMessage, error) {
+		rendered, err := paramsFn(r)
+		if err != nil {
+			return nil, err
+		}
+
+		var params map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(rendered), &params); err != nil {
+			return nil, fmt.Errorf("script params %q are not a JSON object: %w", rendered, err)
+		}
+
+		return params, nil
+	}, nil
+}
+
+// isTemplate reports whether the text contains Go template actions.
+func isTemplate(text string) bool {
+	return strings.Contains(text, "{{") || strings.Contains(text, "}}")
+}
+
+// recordTemplate parses the Go template and returns a function executing it for each record.
+func recordTemplate(name, text string) (func(opencdc.Record) (string, error), error) {
+	t, err := template.New(name).Funcs(sprig.FuncMap()).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 	return func(r opencdc.Record) (string, error) {
 		buf.Reset()
 		if err := t.Execute(&buf, r); err != nil {
-			return "", fmt.Errorf("failed to execute index template: %w", err)
+			return "", fmt.Errorf("failed to execute %s template: %w", name, err)
 		}
 		return buf.String(), nil
 	}, nil
@@ -15,8 +15,14 @@
 package destination
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/jaswdr/faker"
@@ -136,3 +142,575 @@ func TestConfig_IndexFunction(t *testing.T) {
 		require.Contains(t, err.Error(), "failed to execute index template")
 	})
 }
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("script write mode requires a script", func(t *testing.T) {
+		config := Config{
+			WriteMode: api.WriteModeScript,
+		}
+
+		require.EqualError(t, config.Validate(), `"script" or "scriptID" is required when "writeMode" is "script"`)
+
+		config.Script = "ctx._source.putAll(params.doc)"
+		require.NoError(t, config.Validate())
+
+		config.ScriptID = "merge-document"
+		require.EqualError(t, config.Validate(), `"scriptID" is not supported when "script" is set`)
+
+		config.Script = ""
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("dead-letter index error policy requires an index", func(t *testing.T) {
+		config := Config{
+			ErrorPolicy: ErrorPolicyDeadLetterIndex,
+		}
+
+		require.EqualError(t, config.Validate(), `"deadLetterIndex" is required when "errorPolicy" is "deadLetterIndex"`)
+
+		config.DeadLetterIndex = "dead-letters"
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("rollover requires an ILM policy", func(t *testing.T) {
+		config := Config{
+			Version:  elasticsearch.Version7,
+			Rollover: true,
+		}
+
+		require.EqualError(t, config.Validate(), `"ilmPolicy" is required when "rollover" is enabled`)
+
+		config.ILMPolicy = "logs-policy"
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("version template requires the index write mode", func(t *testing.T) {
+		config := Config{
+			WriteMode:       api.WriteModeUpdate,
+			VersionTemplate: `{{ .Key.version }}`,
+		}
+
+		require.EqualError(t, config.Validate(), `"versionTemplate" requires "writeMode" to be "index"`)
+
+		config.WriteMode = api.WriteModeIndex
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("pipeline requires a write mode creating or replacing documents", func(t *testing.T) {
+		config := Config{
+			WriteMode: api.WriteModeUpdate,
+			Pipeline:  "geoip",
+		}
+
+		require.EqualError(t, config.Validate(), `"pipeline" requires "writeMode" to be "index" or "create", or "dataStream" to be enabled`)
+
+		config.WriteMode = api.WriteModeCreate
+		require.NoError(t, config.Validate())
+
+		config.WriteMode = api.WriteModeUpdate
+		config.DataStream = true
+		config.Version = elasticsearch.Version7
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("update diff requires the update write mode", func(t *testing.T) {
+		config := Config{
+			WriteMode:  api.WriteModeIndex,
+			UpdateDiff: true,
+		}
+
+		require.EqualError(t, config.Validate(), `"updateDiff" requires "writeMode" to be "update"`)
+
+		config.WriteMode = api.WriteModeUpdate
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("schema mappings do not support document transformation", func(t *testing.T) {
+		config := Config{
+			SchemaMappings: true,
+			RenameFields:   []string{"name:full_name"},
+		}
+
+		require.EqualError(t, config.Validate(), `"schemaMappings" is not supported when the Documents are transformed`)
+
+		config.SchemaMappings = false
+		require.NoError(t, config.Validate())
+
+		config.RenameFields = []string{"name"}
+		require.EqualError(t, config.Validate(), `invalid "renameFields": "name" is not a valid rename, expected "from:to"`)
+	})
+
+	t.Run("adaptive bulk size requires consistent bounds", func(t *testing.T) {
+		config := Config{
+			BulkSize:                  100,
+			AdaptiveBulkSize:          true,
+			AdaptiveBulkMinSize:       200,
+			AdaptiveBulkTargetLatency: time.Second,
+		}
+
+		require.EqualError(t, config.Validate(), `"adaptiveBulkMinSize" must not be greater than "bulkSize"`)
+
+		config.AdaptiveBulkMinSize = 10
+		require.NoError(t, config.Validate())
+
+		config.AdaptiveBulkTargetLatency = 0
+		require.EqualError(t, config.Validate(), `"adaptiveBulkTargetLatency" must be positive when "adaptiveBulkSize" is enabled`)
+	})
+
+	t.Run("wait for active shards must be a positive number or all", func(t *testing.T) {
+		for _, shards := range []string{"0", "-1", "any"} {
+			require.EqualError(t, Config{WaitForActiveShards: shards}.Validate(),
+				`"waitForActiveShards" must be a positive number or "all"`)
+		}
+
+		for _, shards := range []string{"", "1", "all"} {
+			require.NoError(t, Config{WaitForActiveShards: shards}.Validate())
+		}
+	})
+
+	t.Run("data stream requires version 7 or 8", func(t *testing.T) {
+		config := Config{
+			Version:    elasticsearch.Version6,
+			DataStream: true,
+		}
+
+		require.EqualError(t, config.Validate(), `"dataStream" requires "version" to be "7" or "8"`)
+
+		config.Version = elasticsearch.Version8
+		require.NoError(t, config.Validate())
+		require.Equal(t, api.WriteModeCreate, config.GetWriteMode())
+	})
+
+	t.Run("data stream dead-letter index policy requires an index", func(t *testing.T) {
+		config := Config{
+			Version:                elasticsearch.Version7,
+			DataStream:             true,
+			DataStreamDeletePolicy: DataStreamPolicyDeadLetterIndex,
+		}
+
+		require.EqualError(t, config.Validate(), `"deadLetterIndex" is required when "dataStreamDeletePolicy" is "deadLetterIndex"`)
+	})
+
+	t.Run("index template requires patterns", func(t *testing.T) {
+		config := Config{
+			IndexTemplate:     "users",
+			IndexTemplateType: api.TemplateTypeIndex,
+		}
+
+		require.EqualError(t, config.Validate(), `"indexTemplatePatterns" is required when "indexTemplate" is set`)
+
+		config.IndexTemplatePatterns = []string{"users-*"}
+		require.NoError(t, config.Validate())
+	})
+
+	t.Run("other write modes do not require a script", func(t *testing.T) {
+		for _, writeMode := range []string{api.WriteModeUpdate, api.WriteModeIndex, api.WriteModeCreate} {
+			require.NoError(t, Config{WriteMode: writeMode}.Validate())
+		}
+	})
+}
+
+func TestConfig_VersionFunction(t *testing.T) {
+	t.Run("template", func(t *testing.T) {
+		config := Config{
+			VersionTemplate: `{{ index .Metadata "opencdc.readAt" }}`,
+		}
+
+		versionFn, err := config.VersionFunction()
+		require.NoError(t, err)
+
+		version, err := versionFn(opencdc.Record{
+			Metadata: map[string]string{"opencdc.readAt": "1700000000000000000"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1700000000000000000), version)
+	})
+
+	t.Run("invalid template syntax", func(t *testing.T) {
+		config := Config{
+			VersionTemplate: "{{ invalid syntax }}",
+		}
+
+		versionFn, err := config.VersionFunction()
+		require.Nil(t, versionFn)
+		require.ErrorContains(t, err, "version template is not a valid Go template")
+	})
+
+	t.Run("not a non-negative integer", func(t *testing.T) {
+		config := Config{
+			VersionTemplate: `{{ .Key.version }}`,
+		}
+
+		versionFn, err := config.VersionFunction()
+		require.NoError(t, err)
+
+		for _, value := range []any{"abc", -1, ""} {
+			_, err := versionFn(opencdc.Record{
+				Key: opencdc.StructuredData{"version": value},
+			})
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestConfig_RoutingFunction(t *testing.T) {
+	config := Config{
+		Routing: `{{ .Payload.After.tenant }}`,
+	}
+
+	routingFn, err := config.RoutingFunction()
+	require.NoError(t, err)
+
+	t.Run("upsert", func(t *testing.T) {
+		routing, err := routingFn(sdk.SourceUtil{}.NewRecordCreate(
+			nil,
+			nil,
+			opencdc.RawData("1"),
+			opencdc.StructuredData{"tenant": "a"},
+		))
+		require.NoError(t, err)
+		require.Equal(t, "a", routing)
+	})
+
+	t.Run("delete is routed by the before payload", func(t *testing.T) {
+		routing, err := routingFn(sdk.SourceUtil{}.NewRecordDelete(
+			nil,
+			nil,
+			opencdc.RawData("1"),
+			opencdc.StructuredData{"tenant": "a"},
+		))
+		require.NoError(t, err)
+		require.Equal(t, "a", routing)
+	})
+
+	t.Run("invalid template syntax", func(t *testing.T) {
+		routingFn, err := Config{Routing: "{{ invalid syntax }}"}.RoutingFunction()
+		require.Nil(t, routingFn)
+		require.ErrorContains(t, err, "routing template is not a valid Go template")
+	})
+}
+
+func TestConfig_PipelineFunction(t *testing.T) {
+	record := opencdc.Record{
+		Metadata: map[string]string{"opencdc.collection": "users"},
+	}
+
+	t.Run("static", func(t *testing.T) {
+		pipelineFn, err := Config{Pipeline: "geoip"}.PipelineFunction()
+		require.NoError(t, err)
+
+		pipeline, err := pipelineFn(record)
+		require.NoError(t, err)
+		require.Equal(t, "geoip", pipeline)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		pipelineFn, err := Config{Pipeline: `{{ index .Metadata "opencdc.collection" }}-pipeline`}.PipelineFunction()
+		require.NoError(t, err)
+
+		pipeline, err := pipelineFn(record)
+		require.NoError(t, err)
+		require.Equal(t, "users-pipeline", pipeline)
+	})
+
+	t.Run("invalid template syntax", func(t *testing.T) {
+		pipelineFn, err := Config{Pipeline: "{{ invalid syntax }}"}.PipelineFunction()
+		require.Nil(t, pipelineFn)
+		require.ErrorContains(t, err, "pipeline is neither a valid static pipeline nor a valid Go template")
+	})
+}
+
+func TestConfig_ScriptParamsFunction(t *testing.T) {
+	t.Run("template", func(t *testing.T) {
+		paramsFn, err := Config{
+			ScriptParams: `{"count": {{ index .Payload.After "count" }}, "tag": {{ index .Payload.After "tag" | toJson }}}`,
+		}.ScriptParamsFunction()
+		require.NoError(t, err)
+
+		params, err := paramsFn(opencdc.Record{
+			Payload: opencdc.Change{
+				After: opencdc.StructuredData{"count": 2, "tag": "new"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]json.RawMessage{
+			"count": json.RawMessage(`2`),
+			"tag":   json.RawMessage(`"new"`),
+		}, params)
+	})
+
+	t.Run("not a JSON object", func(t *testing.T) {
+		paramsFn, err := Config{ScriptParams: `{{ .Position }}`}.ScriptParamsFunction()
+		require.NoError(t, err)
+
+		_, err = paramsFn(opencdc.Record{Position: opencdc.Position("1")})
+		require.ErrorContains(t, err, "are not a JSON object")
+	})
+}
+
+func TestConfig_TransformFunction(t *testing.T) {
+	metadata := opencdc.Metadata{}
+	metadata.SetCollection("users")
+	metadata.SetReadAt(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	record := opencdc.Record{
+		Position:  opencdc.Position("42"),
+		Operation: opencdc.OperationUpdate,
+		Metadata:  metadata,
+		Payload: opencdc.Change{
+			Before: opencdc.StructuredData{"name": "John", "secret": "s3cr3t"},
+			After: opencdc.RawData(`{"name":"Jane","secret":"s3cr3t","phone":null,` +
+				`"address":{"city":"Paris","zip":null}}`),
+		},
+	}
+
+	t.Run("shapes the fields and injects the metadata", func(t *testing.T) {
+		transform, err := Config{
+			ExcludeFields:        []string{"secret"},
+			RenameFields:         []string{"name:full_name"},
+			FlattenFields:        true,
+			DropNullFields:       true,
+			MetadataFields:       []string{MetadataFieldCollection, MetadataFieldPosition, MetadataFieldReadAt},
+			MetadataFieldsPrefix: "_conduit_",
+		}.TransformFunction()
+		require.NoError(t, err)
+
+		transformed, err := transform(record)
+		require.NoError(t, err)
+		require.Equal(t, opencdc.StructuredData{"full_name": "John"}, transformed.Payload.Before)
+		require.Equal(t, opencdc.StructuredData{
+			"full_name":           "Jane",
+			"address.city":        "Paris",
+			"_conduit_collection": "users",
+			"_conduit_position":   "42",
+			"_conduit_readAt":     "2024-01-02T03:04:05Z",
+		}, transformed.Payload.After)
+	})
+
+	t.Run("keeps only the included fields", func(t *testing.T) {
+		transform, err := Config{
+			IncludeFields:  []string{"address"},
+			DropNullFields: true,
+		}.TransformFunction()
+		require.NoError(t, err)
+
+		transformed, err := transform(record)
+		require.NoError(t, err)
+		require.Equal(t, opencdc.StructuredData{
+			"address": map[string]interface{}{"city": "Paris"},
+		}, transformed.Payload.After)
+	})
+
+	t.Run("invalid rename", func(t *testing.T) {
+		_, err := Config{RenameFields: []string{"name"}}.TransformFunction()
+		require.EqualError(t, err, `"name" is not a valid rename, expected "from:to"`)
+	})
+
+	t.Run("unknown metadata field", func(t *testing.T) {
+		_, err := Config{MetadataFields: []string{"key"}}.TransformFunction()
+		require.EqualError(t, err, `unknown metadata field "key"`)
+	})
+}
+
+func TestConfig_IndexDefinition(t *testing.T) {
+	settingsFile := filepath.Join(t.TempDir(), "settings.json")
+	require.NoError(t, os.WriteFile(settingsFile, []byte(`{"number_of_shards": 1}`), 0o600))
+
+	t.Run("inline mappings and settings file", func(t *testing.T) {
+		definition, err := Config{
+			IndexMappings: `{"properties": {"name": {"type": "keyword"}}}`,
+			IndexSettings: settingsFile,
+		}.IndexDefinition()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"properties": {"name": {"type": "keyword"}}}`, string(definition.Mappings))
+		require.JSONEq(t, `{"number_of_shards": 1}`, string(definition.Settings))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		definition, err := Config{}.IndexDefinition()
+		require.NoError(t, err)
+		require.Empty(t, definition.Mappings)
+		require.Empty(t, definition.Settings)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := Config{IndexMappings: `{"properties":`}.IndexDefinition()
+		require.EqualError(t, err, "invalid index mappings: not a valid JSON")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Config{IndexSettings: filepath.Join(t.TempDir(), "missing.json")}.IndexDefinition()
+		require.ErrorContains(t, err, "invalid index settings")
+	})
+}
+
+func TestConfig_DocumentIDFunction(t *testing.T) {
+	structuredKeyRecord := sdk.SourceUtil{}.NewRecordCreate(
+		nil,
+		map[string]string{"opencdc.collection": "users"},
+		opencdc.StructuredData{"tenant": "a", "id": 1},
+		nil,
+	)
+
+	t.Run("template", func(t *testing.T) {
+		config := Config{
+			IDTemplate: `{{ index .Metadata "opencdc.collection" }}-{{ .Key.id }}`,
+		}
+
+		idFn, err := config.DocumentIDFunction()
+		require.NoError(t, err)
+
+		id, err := idFn(structuredKeyRecord)
+		require.NoError(t, err)
+		require.Equal(t, "users-1", id)
+	})
+
+	t.Run("invalid template syntax", func(t *testing.T) {
+		config := Config{
+			IDTemplate: "{{ invalid syntax }}",
+		}
+
+		idFn, err := config.DocumentIDFunction()
+		require.Error(t, err)
+		require.Nil(t, idFn)
+		require.Contains(t, err.Error(), "ID template is not a valid Go template")
+	})
+
+	t.Run("json key format", func(t *testing.T) {
+		idFn, err := Config{KeyFormat: KeyFormatJSON}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		id, err := idFn(structuredKeyRecord)
+		require.NoError(t, err)
+		require.Equal(t, `{"id":1,"tenant":"a"}`, id)
+	})
+
+	t.Run("join key format", func(t *testing.T) {
+		idFn, err := Config{KeyFormat: KeyFormatJoin, KeySeparator: ":"}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		id, err := idFn(structuredKeyRecord)
+		require.NoError(t, err)
+		require.Equal(t, "1:a", id)
+
+		id, err = idFn(sdk.SourceUtil{}.NewRecordCreate(nil, nil, opencdc.RawData("raw-key"), nil))
+		require.NoError(t, err)
+		require.Equal(t, "raw-key", id)
+	})
+
+	t.Run("hash key format", func(t *testing.T) {
+		idFn, err := Config{KeyFormat: KeyFormatHash}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		id, err := idFn(structuredKeyRecord)
+		require.NoError(t, err)
+		require.Len(t, id, 64)
+
+		sameKeyRecord := structuredKeyRecord.Clone()
+		sameKeyRecord.Key = opencdc.StructuredData{"id": 1, "tenant": "a"}
+
+		sameID, err := idFn(sameKeyRecord)
+		require.NoError(t, err)
+		require.Equal(t, id, sameID)
+	})
+
+	t.Run("record without key", func(t *testing.T) {
+		for _, keyFormat := range []string{KeyFormatJSON, KeyFormatJoin, KeyFormatHash} {
+			idFn, err := Config{KeyFormat: keyFormat}.DocumentIDFunction()
+			require.NoError(t, err)
+
+			id, err := idFn(sdk.SourceUtil{}.NewRecordCreate(nil, nil, nil, nil))
+			require.NoError(t, err)
+			require.Empty(t, id)
+		}
+	})
+}
+
+func TestConfig_DocumentIDFunction_Keyless(t *testing.T) {
+	newRecord := func(position string, payload opencdc.Data) opencdc.Record {
+		return sdk.SourceUtil{}.NewRecordCreate(
+			opencdc.Position(position),
+			map[string]string{"opencdc.collection": "users"},
+			nil,
+			payload,
+		)
+	}
+
+	t.Run("none strategy leaves the ID empty", func(t *testing.T) {
+		idFn, err := Config{KeylessID: KeylessIDNone}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		id, err := idFn(newRecord("1", opencdc.RawData(`{"id":1}`)))
+		require.NoError(t, err)
+		require.Empty(t, id)
+	})
+
+	t.Run("position strategy", func(t *testing.T) {
+		idFn, err := Config{KeylessID: KeylessIDPosition}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		id, err := idFn(newRecord("1", nil))
+		require.NoError(t, err)
+		require.Len(t, id, 64)
+
+		replayedID, err := idFn(newRecord("1", nil))
+		require.NoError(t, err)
+		require.Equal(t, id, replayedID)
+
+		otherID, err := idFn(newRecord("2", nil))
+		require.NoError(t, err)
+		require.NotEqual(t, id, otherID)
+
+		deleteID, err := idFn(sdk.SourceUtil{}.NewRecordDelete(opencdc.Position("1"), nil, nil, nil))
+		require.NoError(t, err)
+		require.Empty(t, deleteID)
+	})
+
+	t.Run("payload strategy with selected fields", func(t *testing.T) {
+		idFn, err := Config{
+			KeylessID:       KeylessIDPayload,
+			KeylessIDFields: []string{"id", "tenant"},
+		}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		id, err := idFn(newRecord("1", opencdc.StructuredData{"id": 1, "tenant": "a", "name": "foo"}))
+		require.NoError(t, err)
+		require.Len(t, id, 64)
+
+		sameID, err := idFn(newRecord("2", opencdc.RawData(`{"tenant":"a","id":1,"name":"bar"}`)))
+		require.NoError(t, err)
+		require.Equal(t, id, sameID)
+
+		deleteID, err := idFn(sdk.SourceUtil{}.NewRecordDelete(
+			nil,
+			nil,
+			nil,
+			opencdc.StructuredData{"id": 1, "tenant": "a"},
+		))
+		require.NoError(t, err)
+		require.Equal(t, id, deleteID)
+	})
+
+	t.Run("payload strategy fails for invalid JSON", func(t *testing.T) {
+		idFn, err := Config{
+			KeylessID:       KeylessIDPayload,
+			KeylessIDFields: []string{"id"},
+		}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		_, err = idFn(newRecord("1", opencdc.RawData("not JSON")))
+		require.ErrorContains(t, err, "failed to select payload fields")
+	})
+
+	t.Run("key takes precedence", func(t *testing.T) {
+		idFn, err := Config{KeylessID: KeylessIDPosition}.DocumentIDFunction()
+		require.NoError(t, err)
+
+		record := newRecord("1", nil)
+		record.Key = opencdc.RawData("key")
+
+		id, err := idFn(record)
+		require.NoError(t, err)
+		require.Equal(t, "key", id)
+	})
+}
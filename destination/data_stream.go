@@ -0,0 +1,183 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// Policies of handling updates and deletes written to a data stream.
+const (
+	// DataStreamPolicyAppend appends the updated record as a new Document.
+	DataStreamPolicyAppend = "append"
+	// DataStreamPolicySkip logs the record and continues with the rest of the batch.
+	DataStreamPolicySkip = "skip"
+	// DataStreamPolicyFail stops writing at the record.
+	DataStreamPolicyFail = "fail"
+	// DataStreamPolicyDeadLetterIndex writes the record to the dead-letter index.
+	DataStreamPolicyDeadLetterIndex = "deadLetterIndex"
+)
+
+// dataStreamTimestampField is the field holding the time of the Documents in a data stream.
+const dataStreamTimestampField = "@timestamp"
+
+// writeDataStreamOperation adds the operation of appending the record to the data stream into Bulk API request.
+// Updates and deletes are handled according to their data stream policies. It reports whether an operation was added.
+func (d *Destination) writeDataStreamOperation(
+	ctx context.Context,
+	data *bytes.Buffer,
+	record opencdc.Record,
+	key string,
+	index string,
+	options api.BulkOperationOptions,
+) (bool, error) {
+	var policy string
+	switch record.Operation {
+	case opencdc.OperationUpdate:
+		policy = d.config.DataStreamUpdatePolicy
+	case opencdc.OperationDelete:
+		policy = d.config.DataStreamDeletePolicy
+	}
+
+	switch policy {
+	case "", DataStreamPolicyAppend:
+		record, err := d.withTimestamp(record)
+		if err != nil {
+			return false, err
+		}
+
+		// Updates are appended as new Documents, so they must not collide with the created ones
+		if key == "" || policy == DataStreamPolicyAppend {
+			return true, d.writeInsertOperation(data, record, index, options)
+		}
+
+		return true, d.writeUpsertOperation(key, data, record, index, options)
+
+	case DataStreamPolicySkip:
+		sdk.Logger(ctx).Debug().
+			Str("operation", record.Operation.String()).
+			Str("id", key).
+			Msg("operation not supported by data streams, skipping")
+
+		return false, nil
+
+	case DataStreamPolicyDeadLetterIndex:
+		deadLetter, err := newDeadLetter(record, rejectedItem{
+			response: bulkResponseItem{
+				Index: index,
+				ID:    key,
+				Error: &bulkResponseItemError{
+					Type:   "unsupported_operation",
+					Reason: "data streams only support appending documents",
+				},
+			},
+			operationType: record.Operation.String(),
+		})
+		if err != nil {
+			return false, err
+		}
+
+		if err := d.writeInsertOperation(data, deadLetter, d.config.DeadLetterIndex, api.BulkOperationOptions{}); err != nil {
+			return false, fmt.Errorf("failed to prepare dead letter: %w", err)
+		}
+
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("operation %v on record %v not supported by data streams", record.Operation, record.Key)
+	}
+}
+
+// withTimestamp returns the record with the `@timestamp` field in its payload.
+// The timestamp is copied from the configured payload field, otherwise an existing one is kept
+// or it's set from the configured metadata field.
+func (d *Destination) withTimestamp(record opencdc.Record) (opencdc.Record, error) {
+	payload, err := structuredPayload(record.Payload.After)
+	if err != nil {
+		return opencdc.Record{}, err
+	}
+
+	switch {
+	case d.config.DataStreamTimestampField != "":
+		timestamp, ok := payload[d.config.DataStreamTimestampField]
+		if !ok {
+			return opencdc.Record{}, fmt.Errorf("timestamp field %q not found in payload", d.config.DataStreamTimestampField)
+		}
+
+		payload[dataStreamTimestampField] = timestamp
+
+	case payload[dataStreamTimestampField] != nil:
+		return record, nil
+
+	default:
+		timestamp, err := metadataTimestamp(record.Metadata, d.config.DataStreamTimestampMetadata)
+		if err != nil {
+			return opencdc.Record{}, err
+		}
+
+		payload[dataStreamTimestampField] = timestamp.UTC().Format(time.RFC3339Nano)
+	}
+
+	record.Payload.After = payload
+
+	return record, nil
+}
+
+// metadataTimestamp returns the time stored in the metadata field.
+func metadataTimestamp(metadata opencdc.Metadata, field string) (time.Time, error) {
+	if field == opencdc.MetadataCreatedAt {
+		return metadata.GetCreatedAt()
+	}
+
+	return metadata.GetReadAt()
+}
+
+// structuredPayload returns a copy of the payload as structured data. Raw payloads are decoded from JSON.
+func structuredPayload(data opencdc.Data) (opencdc.StructuredData, error) {
+	switch data := data.(type) {
+	case opencdc.StructuredData:
+		payload := make(opencdc.StructuredData, len(data)+1)
+		for k, v := range data {
+			payload[k] = v
+		}
+
+		return payload, nil
+
+	case nil:
+		return opencdc.StructuredData{}, nil
+
+	default:
+		payload := opencdc.StructuredData{}
+		if len(data.Bytes()) == 0 {
+			return payload, nil
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data.Bytes()))
+		decoder.UseNumber()
+		if err := decoder.Decode(&payload); err != nil {
+			return nil, fmt.Errorf("failed to decode payload: %w", err)
+		}
+
+		return payload, nil
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// deleteQuery returns the delete by query removing the Documents matching the deleted record without a key.
+// The matched values are taken from the record's `before` payload. It returns nil when deletes are ignored.
+func (d *Destination) deleteQuery(
+	record opencdc.Record,
+	index string,
+	options api.BulkOperationOptions,
+) (*api.DeleteByQueryRequest, error) {
+	switch d.config.DeletePolicy {
+	case DeletePolicyIgnore:
+		return nil, nil
+
+	case DeletePolicySoft:
+		return nil, fmt.Errorf("soft delete of record %v without a key is not supported", record.Position)
+	}
+
+	if len(d.config.DeleteByQueryFields) == 0 {
+		return nil, fmt.Errorf("delete of record %v without a key requires %q", record.Position, ConfigDeleteByQueryFields)
+	}
+
+	before, err := structuredPayload(record.Payload.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	match := make(map[string]interface{}, len(d.config.DeleteByQueryFields))
+	for _, field := range d.config.DeleteByQueryFields {
+		value, ok := before[field]
+		if !ok || value == nil {
+			return nil, fmt.Errorf("delete of record %v without a key: field %q not found in the before payload", record.Position, field)
+		}
+
+		match[field] = value
+	}
+
+	return &api.DeleteByQueryRequest{
+		Index:   index,
+		Match:   match,
+		Routing: options.Routing,
+	}, nil
+}
+
+// nextDeleteQuery returns the position of the first item executed as a delete by query,
+// or the number of the items if there is none.
+func nextDeleteQuery(items []bulkItem) int {
+	for i, item := range items {
+		if item.deleteQuery != nil {
+			return i
+		}
+	}
+
+	return len(items)
+}
+
+// executeDeleteQuery executes the delete by query of the item.
+func (d *Destination) executeDeleteQuery(ctx context.Context, item bulkItem) error {
+	deleted, err := d.client.DeleteByQuery(ctx, item.deleteQuery)
+	if err != nil {
+		return fmt.Errorf("delete by query failure: %w", err)
+	}
+
+	// Nothing matches string values mapped as analyzed text, or Documents not yet visible to search
+	if deleted == 0 {
+		sdk.Logger(ctx).Warn().
+			Str("index", item.deleteQuery.Index).
+			Int("record", item.record).
+			Msg("no documents matched the delete by query")
+
+		return nil
+	}
+
+	sdk.Logger(ctx).Debug().
+		Str("index", item.deleteQuery.Index).
+		Int64("deleted", deleted).
+		Msg("documents deleted by query")
+
+	return nil
+}
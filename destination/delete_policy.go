@@ -0,0 +1,114 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// Policies of handling delete operations.
+const (
+	// DeletePolicyHard deletes the Document.
+	DeletePolicyHard = "hard"
+	// DeletePolicySoft keeps the Document as a tombstone marked as deleted.
+	DeletePolicySoft = "soft"
+	// DeletePolicyIgnore leaves the Document untouched.
+	DeletePolicyIgnore = "ignore"
+)
+
+// writeDeleteByPolicy adds the operation handling the delete according to the delete policy into Bulk API request.
+// It reports whether an operation was added.
+func (d *Destination) writeDeleteByPolicy(
+	ctx context.Context,
+	data *bytes.Buffer,
+	record opencdc.Record,
+	key string,
+	index string,
+	options api.BulkOperationOptions,
+) (bool, error) {
+	switch d.config.DeletePolicy {
+	case DeletePolicyIgnore:
+		sdk.Logger(ctx).Debug().
+			Str("id", key).
+			Msg("delete ignored by the delete policy, skipping")
+
+		return false, nil
+
+	case DeletePolicySoft:
+		return true, d.writeSoftDeleteOperation(key, data, record, index, options)
+
+	default:
+		return true, d.writeDeleteOperation(key, data, index, options)
+	}
+}
+
+// writeSoftDeleteOperation adds mark a Document with ID as deleted request into Bulk API request.
+func (d *Destination) writeSoftDeleteOperation(
+	key string,
+	data *bytes.Buffer,
+	item opencdc.Record,
+	index string,
+	options api.BulkOperationOptions,
+) error {
+	jsonEncoder := json.NewEncoder(data)
+
+	// Prepare data
+	metadata, payload, err := d.client.PrepareSoftDeleteOperation(key, index, d.softDeleteDoc(item), options)
+	if err != nil {
+		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
+	}
+
+	// Write metadata
+	if err := jsonEncoder.Encode(metadata); err != nil {
+		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
+	}
+
+	// Write payload
+	if err := jsonEncoder.Encode(payload); err != nil {
+		return fmt.Errorf("failed to prepare data with key=%s: %w", key, err)
+	}
+
+	return nil
+}
+
+// softDeleteDoc returns the fields marking the Document as deleted.
+// The time of the deletion is taken from the record's metadata, preferring the time the record was created at.
+func (d *Destination) softDeleteDoc(record opencdc.Record) opencdc.StructuredData {
+	doc := opencdc.StructuredData{
+		d.config.SoftDeleteField: true,
+	}
+
+	if d.config.SoftDeleteTimeField != "" {
+		deletedAt, err := record.Metadata.GetCreatedAt()
+		if err != nil {
+			deletedAt, err = record.Metadata.GetReadAt()
+		}
+		if err != nil {
+			deletedAt = time.Now()
+		}
+
+		doc[d.config.SoftDeleteTimeField] = deletedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return doc
+}
@@ -20,11 +20,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"time"
 
 	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/config"
 	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/conduitio/conduit-commons/schema"
 	sdk "github.com/conduitio/conduit-connector-sdk"
+	sdkschema "github.com/conduitio/conduit-connector-sdk/schema"
 )
 
 func NewDestination() sdk.Destination {
@@ -34,8 +39,27 @@ func NewDestination() sdk.Destination {
 type Destination struct {
 	sdk.UnimplementedDestination
 
-	config       Config
-	getIndexName IndexFn
+	config          Config
+	getIndexName    IndexFn
+	getDocumentID   IDFn
+	getVersion      VersionFn
+	getRouting      RoutingFn
+	getPipeline     PipelineFn
+	getScriptParams ScriptParamsFn
+	transform       TransformFn
+
+	indexDefinition api.IndexDefinition
+	ensuredIndices  map[string]struct{}
+
+	getSchema           func(ctx context.Context, subject string, version int) (schema.Schema, error)
+	schemaMappingsCache map[string]json.RawMessage
+	schemaMappedIndices map[string]struct{}
+
+	// bulkSizer adapts the bulk requests to the backpressure of the cluster, nil unless enabled
+	bulkSizer *adaptiveBulkSizer
+
+	// bulkBufferSize is the size of the previous batch, the buffer of the next one is grown to up front
+	bulkBufferSize int
 
 	client client
 }
@@ -58,11 +82,71 @@ func (d *Destination) Configure(ctx context.Context, cfg config.Config) (err err
 		return err
 	}
 
+	if err := d.config.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
 	d.getIndexName, err = d.config.IndexFunction()
 	if err != nil {
 		return fmt.Errorf("invalid index name or index function: %w", err)
 	}
 
+	d.getDocumentID, err = d.config.DocumentIDFunction()
+	if err != nil {
+		return fmt.Errorf("invalid document ID template: %w", err)
+	}
+
+	if d.config.VersionTemplate != "" {
+		d.getVersion, err = d.config.VersionFunction()
+		if err != nil {
+			return fmt.Errorf("invalid version template: %w", err)
+		}
+	}
+
+	if d.config.Routing != "" {
+		d.getRouting, err = d.config.RoutingFunction()
+		if err != nil {
+			return fmt.Errorf("invalid routing template: %w", err)
+		}
+	}
+
+	if d.config.Pipeline != "" {
+		d.getPipeline, err = d.config.PipelineFunction()
+		if err != nil {
+			return fmt.Errorf("invalid pipeline: %w", err)
+		}
+	}
+
+	if d.config.ScriptParams != "" {
+		d.getScriptParams, err = d.config.ScriptParamsFunction()
+		if err != nil {
+			return fmt.Errorf("invalid script params template: %w", err)
+		}
+	}
+
+	if d.config.transformsDocuments() {
+		d.transform, err = d.config.TransformFunction()
+		if err != nil {
+			return fmt.Errorf("invalid document transformation: %w", err)
+		}
+	}
+
+	if d.config.AdaptiveBulkSize {
+		d.bulkSizer = newAdaptiveBulkSizer(
+			d.config.AdaptiveBulkMinSize,
+			d.config.BulkSize,
+			d.config.AdaptiveBulkTargetLatency,
+			d.config.AdaptiveBulkMaxDelay,
+		)
+	}
+
+	d.indexDefinition, err = d.config.IndexDefinition()
+	if err != nil {
+		return fmt.Errorf("invalid index definition: %w", err)
+	}
+
+	d.getSchema = sdkschema.Get
+
 	return
 }
 
@@ -78,134 +162,327 @@ func (d *Destination) Open(ctx context.Context) (err error) {
 		return fmt.Errorf("server cannot be pinged: %w", err)
 	}
 
+	if err := d.checkPipeline(ctx); err != nil {
+		return err
+	}
+
+	if err := d.ensureTemplate(ctx); err != nil {
+		return err
+	}
+
+	// Templated indices are created once they are seen in the written records
+	if d.createsIndices() && !isTemplate(d.config.Index) {
+		if err := d.ensureIndex(ctx, d.config.Index); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (d *Destination) Write(ctx context.Context, records []opencdc.Record) (int, error) {
-	// Execute operations
-	// todo return retries
+// checkPipeline checks the static ingest pipeline exists, templated ones are checked by Elasticsearch for each Document.
+func (d *Destination) checkPipeline(ctx context.Context) error {
+	if d.config.Pipeline == "" || isTemplate(d.config.Pipeline) {
+		return nil
+	}
 
-	// Prepare request payload
-	data, err := d.prepareBulkRequestPayload(records)
+	exists, err := d.client.PipelineExists(ctx, d.config.Pipeline)
 	if err != nil {
+		return fmt.Errorf("failed to check ingest pipeline: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("ingest pipeline %q does not exist", d.config.Pipeline)
+	}
+
+	return nil
+}
+
+func (d *Destination) Write(ctx context.Context, records []opencdc.Record) (int, error) {
+	if err := d.ensureIndices(ctx, records); err != nil {
 		return 0, err
 	}
 
-	// Send the bulk request
-	response, err := d.executeBulkRequest(ctx, data)
-	if err != nil {
+	if err := d.ensureSchemaMappings(ctx, records); err != nil {
 		return 0, err
 	}
 
-	// NB: The order of responses is the same as the order of requests
-	// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html#bulk-api-response-body
-	for n, item := range response.Items {
-		// Detect operation result
-		var itemResponse bulkResponseItem
-		var operationType string
+	// Prepare request items, the buffer holding their data is reused once they are written
+	data := getBulkBuffer()
+	defer putBulkBuffer(data)
 
-		switch {
-		case item.Index != nil:
-			itemResponse = *item.Index
-			operationType = "index"
+	data.Grow(d.bulkBufferSize)
+	items, err := d.prepareBulkItems(ctx, data, records)
+	if err != nil {
+		return 0, err
+	}
+	d.bulkBufferSize = data.Len()
 
-		case item.Create != nil:
-			itemResponse = *item.Create
-			operationType = "create"
+	// Send the bulk requests one after another, the deletes by query in between them keep the order of records
+	for len(items) > 0 {
+		next := nextDeleteQuery(items)
+		if n, err := d.writeBulkPartitions(ctx, records, items[:next]); err != nil {
+			return n, err
+		}
 
-		case item.Update != nil:
-			itemResponse = *item.Update
-			operationType = "update"
+		if next == len(items) {
+			break
+		}
 
-		case item.Delete != nil:
-			itemResponse = *item.Delete
-			operationType = "delete"
+		if err := d.executeDeleteQuery(ctx, items[next]); err != nil {
+			return items[next].record, err
+		}
 
-		default:
-			sdk.Logger(ctx).Warn().Msg("no index, create, update or delete details were found in Elasticsearch response")
+		items = items[next+1:]
+	}
 
-			continue
+	return len(records), nil
+}
+
+// writeBulkChunks sends the items in the bulk requests one after another.
+// It returns the number of written records preceding the first failed item and an error.
+func (d *Destination) writeBulkChunks(ctx context.Context, records []opencdc.Record, items []bulkItem) (int, error) {
+	for len(items) > 0 {
+		// The writing is stopped between the requests, e.g. when another bulk worker fails
+		if err := ctx.Err(); err != nil {
+			return items[0].record, err
 		}
 
-		if (itemResponse.Status >= 200 && itemResponse.Status < 300) || itemResponse.Status == 404 {
-			continue
+		bulkSize, delay := d.bulkLimits()
+
+		chunk := d.nextBulkChunk(items, bulkSize)
+		items = items[len(chunk):]
+
+		// The requests are paced while the cluster is overloaded
+		if delay > 0 {
+			if err := waitForRetry(ctx, delay); err != nil {
+				return chunk[0].record, err
+			}
 		}
 
-		if itemResponse.Error == nil {
-			return n + 1, fmt.Errorf(
-				"item with key=%s %s failure: unknown error status: %d",
-				itemResponse.ID,
-				operationType,
-				itemResponse.Status,
-			)
+		rejected, n, err := d.writeBulkItems(ctx, chunk)
+		if err != nil {
+			// The records rejected before the failure are reported as written, so the error policy handles them first
+			rejected = rejectedBefore(rejected, n)
+			if err := d.handleRejectedItems(ctx, records, rejected); err != nil {
+				return firstRejectedRecord(rejected), err
+			}
+
+			return n, err
 		}
 
-		return n + 1, fmt.Errorf(
-			"item with key=%s %s failure: [%s] %s: %s",
-			itemResponse.ID,
-			operationType,
-			itemResponse.Error.Type,
-			itemResponse.Error.Reason,
-			itemResponse.Error.CausedBy,
-		)
+		if err := d.handleRejectedItems(ctx, records, rejected); err != nil {
+			return firstRejectedRecord(rejected), err
+		}
 	}
 
-	return len(records), nil
+	return 0, nil
 }
 
 func (d *Destination) Teardown(context.Context) error {
 	return nil // No close routine needed
 }
 
-// prepareBulkRequestPayload converts all pending operations into a valid Elasticsearch Bulk API request.
-func (d *Destination) prepareBulkRequestPayload(records []opencdc.Record) (*bytes.Buffer, error) {
-	data := &bytes.Buffer{}
-
-	for _, record := range records {
-		index, err := d.getIndexName(record)
+// prepareBulkItems converts all pending operations into items of a valid Elasticsearch Bulk API request.
+func (d *Destination) prepareBulkItems(ctx context.Context, data *bytes.Buffer, records []opencdc.Record) ([]bulkItem, error) {
+	targets := make([]bulkTarget, len(records))
+	for i, record := range records {
+		target, err := d.bulkTarget(record)
 		if err != nil {
 			return nil, err
 		}
 
-		var key string
-		if record.Key != nil {
-			key = string(record.Key.Bytes())
-		}
+		targets[i] = target
+	}
+
+	var compacted []bool
+	var superseded [][]int
+	if d.config.CompactOperations {
+		compacted, superseded = d.compactRecords(ctx, records, targets)
+	}
 
-		op := record.Operation
-		if key == "" {
-			op = opencdc.OperationCreate
+	items := make([]bulkItem, 0, len(records))
+	offsets := make([]int, 1, len(records)+1)
+
+	for i, record := range records {
+		// The operation is superseded by a later one on the same Document
+		if compacted != nil && compacted[i] {
+			continue
 		}
-		switch {
-		case key == "":
-			if err := d.writeInsertOperation(data, record, index); err != nil {
-				return nil, err
+
+		index, key, options := targets[i].index, targets[i].key, targets[i].options
+
+		if d.transform != nil {
+			transformed, err := d.transform(record)
+			if err != nil {
+				return nil, fmt.Errorf("failed to transform record %v: %w", record.Position, err)
 			}
 
-		case op == opencdc.OperationSnapshot || op == opencdc.OperationCreate || op == opencdc.OperationUpdate:
-			if err := d.writeUpsertOperation(key, data, record, index); err != nil {
+			record = transformed
+		}
+
+		// Deletes without a key can't be a part of the Bulk API request
+		if key == "" && record.Operation == opencdc.OperationDelete && !d.config.DataStream {
+			query, err := d.deleteQuery(record, index, options)
+			if err != nil {
 				return nil, err
 			}
 
-		case op == opencdc.OperationDelete:
-			if err := d.writeDeleteOperation(key, data, index); err != nil {
-				return nil, err
+			if query != nil {
+				items = append(items, bulkItem{
+					record:      i,
+					deleteQuery: query,
+				})
+				offsets = append(offsets, data.Len())
 			}
 
-		default:
-			return nil, fmt.Errorf("operation %v on record %v not supported", record.Operation, record.Key)
+			continue
+		}
+
+		written, err := d.writeOperation(ctx, data, record, key, index, options)
+		if err != nil {
+			return nil, err
+		}
+		if !written {
+			continue
+		}
+
+		item := bulkItem{
+			record:  i,
+			docKey:  documentKey(index, key),
+			deletes: record.Operation == opencdc.OperationDelete && !d.config.DataStream,
 		}
+		if superseded != nil {
+			item.superseded = superseded[i]
+		}
+
+		items = append(items, item)
+		offsets = append(offsets, data.Len())
+	}
+
+	// The buffer might have been reallocated while growing, so the items are sliced once it's complete
+	for i := range items {
+		items[i].data = data.Bytes()[offsets[i]:offsets[i+1]]
 	}
 
-	return data, nil
+	return items, nil
+}
+
+// bulkTarget returns the Document targeted by the record's operation along with the operation's options.
+func (d *Destination) bulkTarget(record opencdc.Record) (bulkTarget, error) {
+	index, err := d.getIndexName(record)
+	if err != nil {
+		return bulkTarget{}, err
+	}
+
+	key, err := d.getDocumentID(record)
+	if err != nil {
+		return bulkTarget{}, err
+	}
+
+	options, err := d.bulkOperationOptions(record)
+	if err != nil {
+		return bulkTarget{}, err
+	}
+
+	return bulkTarget{
+		index:   index,
+		key:     key,
+		options: options,
+	}, nil
+}
+
+// writeOperation adds the operation matching the record into Bulk API request.
+// It reports whether an operation was added.
+func (d *Destination) writeOperation(
+	ctx context.Context,
+	data *bytes.Buffer,
+	record opencdc.Record,
+	key string,
+	index string,
+	options api.BulkOperationOptions,
+) (bool, error) {
+	if d.config.DataStream {
+		return d.writeDataStreamOperation(ctx, data, record, key, index, options)
+	}
+
+	op := record.Operation
+	if key == "" {
+		op = opencdc.OperationCreate
+	}
+	switch {
+	case key == "":
+		return true, d.writeInsertOperation(data, record, index, options)
+
+	case op == opencdc.OperationUpdate && d.diffsUpdates():
+		return true, d.writeDiffUpdateOperation(key, data, record, index, options)
+
+	case op == opencdc.OperationSnapshot || op == opencdc.OperationCreate || op == opencdc.OperationUpdate:
+		return true, d.writeUpsertOperation(key, data, record, index, options)
+
+	case op == opencdc.OperationDelete:
+		return d.writeDeleteByPolicy(ctx, data, record, key, index, options)
+
+	default:
+		return false, fmt.Errorf("operation %v on record %v not supported", record.Operation, record.Key)
+	}
+}
+
+// bulkOperationOptions returns the per-record options of the Bulk API request item.
+func (d *Destination) bulkOperationOptions(record opencdc.Record) (api.BulkOperationOptions, error) {
+	var options api.BulkOperationOptions
+
+	if d.getVersion != nil {
+		version, err := d.getVersion(record)
+		if err != nil {
+			return api.BulkOperationOptions{}, err
+		}
+
+		options.Version = &version
+		options.VersionType = d.config.VersionType
+	}
+
+	if d.getRouting != nil {
+		routing, err := d.getRouting(record)
+		if err != nil {
+			return api.BulkOperationOptions{}, err
+		}
+
+		options.Routing = routing
+	}
+
+	if d.getPipeline != nil {
+		pipeline, err := d.getPipeline(record)
+		if err != nil {
+			return api.BulkOperationOptions{}, err
+		}
+
+		options.Pipeline = pipeline
+	}
+
+	if d.getScriptParams != nil && d.config.GetWriteMode() == api.WriteModeScript &&
+		record.Operation != opencdc.OperationDelete {
+		params, err := d.getScriptParams(record)
+		if err != nil {
+			return api.BulkOperationOptions{}, err
+		}
+
+		options.ScriptParams = params
+	}
+
+	return options, nil
 }
 
 // writeInsertOperation adds create new Document without ID request into Bulk API request.
-func (d *Destination) writeInsertOperation(data *bytes.Buffer, item opencdc.Record, index string) error {
+func (d *Destination) writeInsertOperation(
+	data *bytes.Buffer,
+	item opencdc.Record,
+	index string,
+	options api.BulkOperationOptions,
+) error {
 	jsonEncoder := json.NewEncoder(data)
 
 	// Prepare data
-	metadata, payload, err := d.client.PrepareCreateOperation(item, index)
+	metadata, payload, err := d.client.PrepareCreateOperation(item, index, options)
 	if err != nil {
 		return fmt.Errorf("failed to prepare metadata: %w", err)
 	}
@@ -224,11 +501,17 @@ func (d *Destination) writeInsertOperation(data *bytes.Buffer, item opencdc.Reco
 }
 
 // writeUpsertOperation adds upsert a Document with ID request into Bulk API request.
-func (d *Destination) writeUpsertOperation(key string, data *bytes.Buffer, item opencdc.Record, index string) error {
+func (d *Destination) writeUpsertOperation(
+	key string,
+	data *bytes.Buffer,
+	item opencdc.Record,
+	index string,
+	options api.BulkOperationOptions,
+) error {
 	jsonEncoder := json.NewEncoder(data)
 
 	// Prepare data
-	metadata, payload, err := d.client.PrepareUpsertOperation(key, item, index)
+	metadata, payload, err := d.client.PrepareUpsertOperation(key, item, index, options)
 	if err != nil {
 		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
 	}
@@ -247,11 +530,16 @@ func (d *Destination) writeUpsertOperation(key string, data *bytes.Buffer, item
 }
 
 // writeDeleteOperation adds delete a Document by ID request into Bulk API request.
-func (d *Destination) writeDeleteOperation(key string, data *bytes.Buffer, index string) error {
+func (d *Destination) writeDeleteOperation(
+	key string,
+	data *bytes.Buffer,
+	index string,
+	options api.BulkOperationOptions,
+) error {
 	jsonEncoder := json.NewEncoder(data)
 
 	// Prepare data
-	metadata, err := d.client.PrepareDeleteOperation(key, index)
+	metadata, err := d.client.PrepareDeleteOperation(key, index, options)
 	if err != nil {
 		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
 	}
@@ -264,26 +552,109 @@ func (d *Destination) writeDeleteOperation(key string, data *bytes.Buffer, index
 	return nil
 }
 
+// writeBulkItems sends the items in a single Bulk API request, retrying the failed ones.
+// It returns the items rejected by Elasticsearch, ordered by their records, when the error policy tolerates them.
+// Otherwise, it returns the number of written records preceding the first failed item and an error,
+// along with the items rejected until the failure.
+func (d *Destination) writeBulkItems(ctx context.Context, items []bulkItem) ([]rejectedItem, int, error) {
+	var rejected []rejectedItem
+
+	retryBackoff := d.newRetryBackoff()
+
+	for attempt := 0; len(items) > 0; attempt++ {
+		if attempt > 0 {
+			delay := retryBackoff.ForAttempt(float64(attempt - 1))
+
+			sdk.Logger(ctx).Warn().
+				Int("items", len(items)).
+				Int("attempt", attempt).
+				Dur("backoff", delay).
+				Msg("retrying failed bulk items")
+
+			if err := waitForRetry(ctx, delay); err != nil {
+				return sortRejectedItems(rejected), items[0].record, err
+			}
+		}
+
+		// Send the bulk request
+		start := time.Now()
+		response, err := d.executeBulkRequest(ctx, items)
+		if err != nil {
+			// The whole request rejected by the overloaded cluster shrinks the next ones and is sent again
+			if retryableRequestError(err) {
+				d.observeRejectedBulkRequest(ctx, items, time.Since(start))
+
+				if attempt < int(d.config.Retries) {
+					continue
+				}
+			}
+
+			return sortRejectedItems(rejected), items[0].record, err
+		}
+		d.observeBulkRequest(ctx, items, response, time.Since(start))
+
+		var (
+			n             int
+			rejectedItems []rejectedItem
+		)
+
+		items, rejectedItems, n, err = d.handleBulkResponse(ctx, items, response, attempt < int(d.config.Retries))
+		rejected = append(rejected, rejectedItems...)
+
+		if err != nil {
+			return sortRejectedItems(rejected), n, err
+		}
+	}
+
+	return sortRejectedItems(rejected), 0, nil
+}
+
+// bulkRequestOptions returns the options of the Bulk API requests.
+func (d *Destination) bulkRequestOptions() api.BulkRequestOptions {
+	options := api.BulkRequestOptions{
+		WaitForActiveShards: d.config.WaitForActiveShards,
+		Timeout:             d.config.BulkTimeout,
+		FilterPath:          bulkResponseFilterPath,
+	}
+
+	// Not refreshing is the default, so the parameter is sent only when it changes the policy
+	if d.config.Refresh != api.RefreshFalse {
+		options.Refresh = d.config.Refresh
+	}
+
+	return options
+}
+
 // executeBulkRequest executes Bulk API request and parses the response.
-func (d *Destination) executeBulkRequest(ctx context.Context, data *bytes.Buffer) (bulkResponse, error) {
+func (d *Destination) executeBulkRequest(ctx context.Context, items []bulkItem) (bulkResponse, error) {
 	// Check if there is any job to do
-	if data.Len() < 1 {
+	if len(items) < 1 {
 		sdk.Logger(ctx).Info().Msg("no operations to execute in bulk, skipping")
 
 		return bulkResponse{}, nil
 	}
 
-	defer data.Reset()
+	body, release := bulkRequestBody(items)
+	defer release()
 
 	// Execute the request
-	responseBody, err := d.client.Bulk(ctx, bytes.NewReader(data.Bytes()))
+	responseBody, err := d.client.Bulk(ctx, bytes.NewReader(body), d.bulkRequestOptions())
 	if err != nil {
 		return bulkResponse{}, fmt.Errorf("bulk request failure: %w", err)
 	}
 
-	// Get the response
-	bodyContents, err := io.ReadAll(responseBody)
+	// Read individual errors
+	response, err := decodeBulkResponse(responseBody)
 	if err != nil {
+		_ = responseBody.Close()
+
+		return bulkResponse{}, fmt.Errorf("bulk response failure: could not read the response: %w", err)
+	}
+
+	// The rest of the response is drained, so the connection can be reused
+	if _, err := io.Copy(io.Discard, responseBody); err != nil {
+		_ = responseBody.Close()
+
 		return bulkResponse{}, fmt.Errorf("bulk response failure: failed to read the result: %w", err)
 	}
 
@@ -291,11 +662,120 @@ func (d *Destination) executeBulkRequest(ctx context.Context, data *bytes.Buffer
 		return bulkResponse{}, fmt.Errorf("bulk response failure: failed to read the result: %w", err)
 	}
 
-	// Read individual errors
-	var response bulkResponse
-	if err := json.Unmarshal(bodyContents, &response); err != nil {
-		return bulkResponse{}, fmt.Errorf("bulk response failure: could not read the response: %w", err)
+	return response, nil
+}
+
+// handleBulkResponse checks the result of every item of the executed Bulk API request.
+// It returns the items that should be sent again and the items rejected permanently,
+// or the number of written records and an error, along with the items rejected before the failure,
+// when the item can't be retried or the error policy doesn't tolerate rejections.
+func (d *Destination) handleBulkResponse(
+	ctx context.Context,
+	items []bulkItem,
+	response bulkResponse,
+	canRetry bool,
+) ([]bulkItem, []rejectedItem, int, error) {
+	// Every item was applied
+	if !response.Errors {
+		return nil, nil, 0, nil
 	}
 
-	return response, nil
+	if response.Items != len(items) {
+		return nil, nil, items[0].record, fmt.Errorf(
+			"bulk response failure: expected %d items, got %d",
+			len(items),
+			response.Items,
+		)
+	}
+
+	var (
+		retry    []bulkItem
+		rejected []rejectedItem
+	)
+	retriedDocuments := make(map[string]struct{})
+	failures := response.Failures
+
+	// NB: The order of responses is the same as the order of requests
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html#bulk-api-response-body
+	for n := range items {
+		// Only the failed items are kept in the response
+		var failure *bulkResponseFailure
+		if len(failures) > 0 && failures[0].position == n {
+			failure = &failures[0]
+			failures = failures[1:]
+		}
+
+		// An earlier operation on the same document is retried, so this one has to follow it
+		// regardless of its own result, otherwise it could be overwritten with an older state.
+		// Sending an applied operation again is safe, as the scripts, which aren't idempotent,
+		// never share a request with another operation on the same document.
+		if _, ok := retriedDocuments[items[n].docKey]; ok {
+			retry = append(retry, items[n])
+
+			continue
+		}
+
+		if failure == nil {
+			continue
+		}
+
+		// Detect operation result
+		itemResponse, operationType := failure.item.result()
+		if itemResponse == nil {
+			sdk.Logger(ctx).Warn().Msg("no index, create, update or delete details were found in Elasticsearch response")
+
+			continue
+		}
+
+		switch {
+		case items[n].deletes && itemResponse.Status == http.StatusNotFound:
+			// The deleted Document was never indexed or is already deleted
+			continue
+
+		case operationType == "create" && itemResponse.Status == http.StatusConflict:
+			// The Document already exists and must not be overwritten
+			sdk.Logger(ctx).Debug().
+				Str("id", itemResponse.ID).
+				Msg("document already exists, skipping")
+
+			continue
+
+		case d.getVersion != nil && itemResponse.Status == http.StatusConflict:
+			// The stored Document has a newer version, so the stale operation is dropped
+			sdk.Logger(ctx).Debug().
+				Str("id", itemResponse.ID).
+				Msg("document has a newer version, skipping")
+
+			continue
+
+		case canRetry && itemResponse.retryable():
+			retry = append(retry, items[n])
+			if items[n].docKey != "" {
+				retriedDocuments[items[n].docKey] = struct{}{}
+			}
+
+			continue
+		}
+
+		// Temporary failures aren't rejections of the record, so the write fails and the record is redelivered
+		if d.tolerateRejections() && !itemResponse.retryable() {
+			rejected = append(rejected, rejectedItem{
+				item:          items[n],
+				response:      *itemResponse,
+				operationType: operationType,
+			})
+
+			continue
+		}
+
+		// Records preceding the first retried item were written
+		written := items[n].record
+		if len(retry) > 0 {
+			written = retry[0].record
+		}
+
+		return nil, rejected, written, itemResponse.err(operationType)
+	}
+
+	return retry, rejected, 0, nil
 }
@@ -0,0 +1,142 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+func BenchmarkDestination_Write(b *testing.B) {
+	// The large documents show the cost of encoding the payloads into the bulk request
+	testCases := []struct {
+		records      int
+		documentSize int
+	}{
+		{records: 100, documentSize: 1 << 10},
+		{records: 100, documentSize: 1 << 20},
+		{records: 10, documentSize: 16 << 20},
+	}
+
+	for _, tc := range testCases {
+		for _, payload := range []string{"structured", "raw"} {
+			b.Run(fmt.Sprintf("%s/%dKiB", payload, tc.documentSize>>10), func(b *testing.B) {
+				destination := benchmarkDestination(b)
+				records := benchmarkRecords(tc.records, tc.documentSize, payload == "structured")
+
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if _, err := destination.Write(context.Background(), records); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// benchmarkDestination returns the Destination preparing the operations with the Elasticsearch v8 client
+// and discarding the bulk requests.
+func benchmarkDestination(b *testing.B) *Destination {
+	config := Config{
+		Version:      elasticsearch.Version8,
+		Host:         "http://localhost:9200",
+		WriteMode:    api.WriteModeUpdate,
+		BulkSize:     1000,
+		BulkMaxBytes: 100 << 20,
+	}
+
+	client, err := elasticsearch.NewClient(config.Version, config)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return &Destination{
+		config: config,
+		getIndexName: func(_ opencdc.Record) (string, error) {
+			return indexName, nil
+		},
+		getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+		client:        discardBulkClient{Client: client},
+	}
+}
+
+// benchmarkRecords returns the update records with payloads of approximately the given size.
+func benchmarkRecords(count, size int, structured bool) []opencdc.Record {
+	records := make([]opencdc.Record, count)
+	for i := range records {
+		payload := opencdc.StructuredData{
+			"id":   i,
+			"name": "record " + strconv.Itoa(i),
+			"body": strings.Repeat("x", size),
+		}
+
+		var after opencdc.Data = payload
+		if !structured {
+			after = opencdc.RawData(payload.Bytes())
+		}
+
+		records[i] = sdk.SourceUtil{}.NewRecordUpdate(nil, nil, opencdc.RawData(strconv.Itoa(i)), nil, after)
+	}
+
+	return records
+}
+
+// discardBulkClient discards the bulk requests, reporting every operation as successful.
+type discardBulkClient struct {
+	elasticsearch.Client
+}
+
+func (c discardBulkClient) Bulk(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+	counter := &lineCounter{}
+	if _, err := io.Copy(counter, reader); err != nil {
+		return nil, err
+	}
+
+	var response bytes.Buffer
+	response.WriteString(`{"errors":false,"items":[`)
+	for i := 0; i < counter.lines/2; i++ {
+		if i > 0 {
+			response.WriteByte(',')
+		}
+		response.WriteString(`{"update":{"status":200}}`)
+	}
+	response.WriteString(`]}`)
+
+	return io.NopCloser(&response), nil
+}
+
+// lineCounter counts the lines written to it.
+type lineCounter struct {
+	lines int
+}
+
+func (c *lineCounter) Write(p []byte) (int, error) {
+	c.lines += bytes.Count(p, []byte("\n"))
+
+	return len(p), nil
+}
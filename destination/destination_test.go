@@ -18,12 +18,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/conduitio/conduit-commons/schema"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/jaswdr/faker"
 	"github.com/stretchr/testify/require"
@@ -58,7 +65,8 @@ func TestDestination_Write(t *testing.T) {
 			getIndexName: func(_ opencdc.Record) (string, error) {
 				return indexName, nil
 			},
-			client: &esClientMock,
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
 		}
 
 		n, err := destination.Write(context.Background(), nil)
@@ -77,16 +85,16 @@ func TestDestination_Write(t *testing.T) {
 		)
 
 		esClientMock := clientMock{
-			PrepareCreateOperationFunc: func(_ opencdc.Record, _ string) (interface{}, interface{}, error) {
+			PrepareCreateOperationFunc: func(_ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
 				return operationMetadata, operationPayload, nil
 			},
 
-			BulkFunc: func(_ context.Context, reader io.Reader) (io.ReadCloser, error) {
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
 				bulkRequest, err := io.ReadAll(reader)
 				require.NoError(t, err)
 				require.Equal(t, fmt.Sprintf("%q\n%q\n", operationMetadata, operationPayload), string(bulkRequest))
 
-				data, err := json.Marshal(bulkResponse{
+				data, err := json.Marshal(bulkResponseJSON{
 					Took:   0,
 					Errors: false,
 					Items: []bulkResponseItems{
@@ -111,7 +119,8 @@ func TestDestination_Write(t *testing.T) {
 			getIndexName: func(_ opencdc.Record) (string, error) {
 				return indexName, nil
 			},
-			client: &esClientMock,
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
 		}
 
 		n, err := destination.Write(
@@ -134,4 +143,2283 @@ func TestDestination_Write(t *testing.T) {
 		require.Len(t, esClientMock.PrepareDeleteOperationCalls(), 0)
 		require.Len(t, esClientMock.BulkCalls(), 1)
 	})
+	t.Run("Retries only the items rejected with a retryable status", func(t *testing.T) {
+		var bulkRequests []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+
+				if len(bulkRequests) == 1 {
+					return bulkResponseBody(t, http.StatusTooManyRequests, http.StatusOK), nil
+				}
+
+				return bulkResponseBody(t, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Retries:       2,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []string{
+			"\"1\"\n\"1\"\n\"2\"\n\"2\"\n",
+			"\"1\"\n\"1\"\n",
+		}, bulkRequests)
+	})
+
+	t.Run("Retries later operations on the same document to preserve their order", func(t *testing.T) {
+		var bulkRequests []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, string(item.Payload.After.Bytes()), nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+
+				if len(bulkRequests) == 1 {
+					return bulkResponseBody(t, http.StatusServiceUnavailable, http.StatusOK, http.StatusOK), nil
+				}
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Retries:       1,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		records := []opencdc.Record{upsertRecord("1"), upsertRecord("2"), upsertRecord("1")}
+		records[0].Payload.After = opencdc.RawData("old")
+		records[2].Payload.After = opencdc.RawData("new")
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Len(t, bulkRequests, 2)
+		require.Equal(t, "\"1\"\n\"old\"\n\"1\"\n\"new\"\n", bulkRequests[1])
+	})
+
+	t.Run("Sends scripts updating the same document in separate requests", func(t *testing.T) {
+		var bulkRequests []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, string(item.Payload.After.Bytes()), nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+
+				if len(bulkRequests) == 1 {
+					return bulkResponseBody(t, http.StatusConflict, http.StatusOK), nil
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode:     api.WriteModeScript,
+				Script:        "ctx._source.count += 1",
+				Retries:       1,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		records := []opencdc.Record{upsertRecord("1"), upsertRecord("2"), upsertRecord("1")}
+		records[0].Payload.After = opencdc.RawData("first")
+		records[2].Payload.After = opencdc.RawData("second")
+
+		// The second script on the document follows the retried first one, and is applied only once
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Equal(t, []string{
+			"\"1\"\n\"first\"\n\"2\"\n\"2\"\n",
+			"\"1\"\n\"first\"\n",
+			"\"1\"\n\"second\"\n",
+		}, bulkRequests)
+	})
+
+	t.Run("Fails when retries are exhausted", func(t *testing.T) {
+		var bulkCalls int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkCalls++
+				if bulkCalls == 1 {
+					return bulkResponseBody(t, http.StatusOK, http.StatusTooManyRequests), nil
+				}
+
+				return bulkResponseBody(t, http.StatusTooManyRequests), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Retries:       2,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.EqualError(t, err, "item with key= update failure: unknown error status: 429")
+		require.Equal(t, 1, n)
+		require.Len(t, esClientMock.BulkCalls(), 3)
+	})
+
+	t.Run("Does not retry permanent failures", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK, http.StatusBadRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Retries: 2,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+			upsertRecord("3"),
+		})
+		require.EqualError(t, err, "item with key= update failure: unknown error status: 400")
+		require.Equal(t, 2, n)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Retries bulk requests rejected by the overloaded cluster", func(t *testing.T) {
+		var bulkCalls int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkCalls++
+				switch bulkCalls {
+				case 1:
+					return nil, &api.BulkRequestError{
+						StatusCode: http.StatusTooManyRequests,
+						Err:        errors.New("[es_rejected_execution_exception] rejected execution"),
+					}
+				case 2:
+					return nil, &api.BulkRequestError{
+						StatusCode: http.StatusServiceUnavailable,
+						Err:        errors.New("503 Service Unavailable"),
+					}
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Retries:       2,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Len(t, esClientMock.BulkCalls(), 3)
+	})
+
+	t.Run("Fails when retries of a rejected bulk request are exhausted", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return nil, &api.BulkRequestError{
+					StatusCode: http.StatusTooManyRequests,
+					Err:        errors.New("[es_rejected_execution_exception] rejected execution"),
+				}
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Retries:       1,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+		})
+		require.EqualError(t, err, "bulk request failure: [es_rejected_execution_exception] rejected execution")
+		require.Equal(t, 0, n)
+		require.Len(t, esClientMock.BulkCalls(), 2)
+	})
+
+	t.Run("Splits records into bulk requests of the configured size", func(t *testing.T) {
+		var bulkRequests []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 2,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+			upsertRecord("3"),
+			upsertRecord("4"),
+			upsertRecord("5"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5, n)
+		require.Equal(t, []string{
+			"\"1\"\n\"1\"\n\"2\"\n\"2\"\n",
+			"\"3\"\n\"3\"\n\"4\"\n\"4\"\n",
+			"\"5\"\n\"5\"\n",
+		}, bulkRequests)
+		require.Equal(t, bulkResponseFilterPath, esClientMock.BulkCalls()[0].Options.FilterPath)
+	})
+
+	t.Run("Passes the refresh policy, the active shards and the timeout to the bulk requests", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:            10,
+				Refresh:             api.RefreshWaitFor,
+				WaitForActiveShards: "all",
+				BulkTimeout:         30 * time.Second,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{upsertRecord("1")})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Equal(t, api.BulkRequestOptions{
+			Refresh:             api.RefreshWaitFor,
+			WaitForActiveShards: "all",
+			Timeout:             30 * time.Second,
+			FilterPath:          bulkResponseFilterPath,
+		}, esClientMock.BulkCalls()[0].Options)
+
+		destination.config.Refresh = api.RefreshFalse
+
+		_, err = destination.Write(context.Background(), []opencdc.Record{upsertRecord("1")})
+		require.NoError(t, err)
+		require.Empty(t, esClientMock.BulkCalls()[1].Options.Refresh)
+	})
+
+	t.Run("Splits records into bulk requests not exceeding the maximum size", func(t *testing.T) {
+		var bulkRequests []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, string(item.Payload.After.Bytes()), nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:     10,
+				BulkMaxBytes: 16,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		records := []opencdc.Record{upsertRecord("1"), upsertRecord("2"), upsertRecord("3"), upsertRecord("4")}
+		records[2].Payload.After = opencdc.RawData("larger than the limit")
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 4, n)
+		require.Equal(t, []string{
+			"\"1\"\n\"1\"\n\"2\"\n\"2\"\n",
+			"\"3\"\n\"larger than the limit\"\n",
+			"\"4\"\n\"4\"\n",
+		}, bulkRequests)
+	})
+
+	t.Run("Reports records written before a failed bulk request", func(t *testing.T) {
+		var bulkCalls int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkCalls++
+				if bulkCalls == 2 {
+					return nil, errors.New("[http] request entity too large")
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 2,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+			upsertRecord("3"),
+			upsertRecord("4"),
+			upsertRecord("5"),
+		})
+		require.EqualError(t, err, "bulk request failure: [http] request entity too large")
+		require.Equal(t, 2, n)
+		require.Len(t, esClientMock.BulkCalls(), 2)
+	})
+
+	t.Run("Writes partitions concurrently keeping the order of each document", func(t *testing.T) {
+		var (
+			mu           sync.Mutex
+			bulkRequests []string
+		)
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, string(item.Payload.After.Bytes()), nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				mu.Lock()
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+				mu.Unlock()
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:    10,
+				BulkWorkers: 4,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		var records []opencdc.Record
+		for i := 0; i < 20; i++ {
+			record := upsertRecord(strconv.Itoa(i % 5))
+			record.Payload.After = opencdc.RawData(strconv.Itoa(i))
+			records = append(records, record)
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 20, n)
+
+		// The operations on every Document are written in order
+		written := make(map[string][]string)
+		for _, bulkRequest := range bulkRequests {
+			lines := strings.Split(strings.TrimSuffix(bulkRequest, "\n"), "\n")
+			for i := 0; i < len(lines); i += 2 {
+				written[lines[i]] = append(written[lines[i]], lines[i+1])
+			}
+		}
+
+		require.Equal(t, map[string][]string{
+			`"0"`: {`"0"`, `"5"`, `"10"`, `"15"`},
+			`"1"`: {`"1"`, `"6"`, `"11"`, `"16"`},
+			`"2"`: {`"2"`, `"7"`, `"12"`, `"17"`},
+			`"3"`: {`"3"`, `"8"`, `"13"`, `"18"`},
+			`"4"`: {`"4"`, `"9"`, `"14"`, `"19"`},
+		}, written)
+		require.Greater(t, len(bulkRequests), 1)
+	})
+
+	t.Run("Reports records preceding the earliest failure of the concurrent partitions", func(t *testing.T) {
+		// The failure stops the other workers, so it waits for the preceding records to be written
+		var precedingWritten sync.WaitGroup
+		precedingWritten.Add(2)
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				switch {
+				case strings.Contains(string(bulkRequest), "\"3\""):
+					precedingWritten.Wait()
+
+					return nil, errors.New("[http] request entity too large")
+				case strings.Contains(string(bulkRequest), "\"1\""), strings.Contains(string(bulkRequest), "\"2\""):
+					defer precedingWritten.Done()
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:    1,
+				BulkWorkers: 3,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+			upsertRecord("3"),
+			upsertRecord("4"),
+			upsertRecord("3"),
+		})
+		require.EqualError(t, err, "bulk request failure: [http] request entity too large")
+		require.Equal(t, 2, n)
+	})
+
+	t.Run("Stops the other partitions after a failure", func(t *testing.T) {
+		var (
+			mu           sync.Mutex
+			bulkRequests []string
+			firstWritten = make(chan struct{})
+		)
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return string(item.Payload.After.Bytes()), string(item.Payload.After.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				mu.Lock()
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+				mu.Unlock()
+
+				switch {
+				case strings.Contains(string(bulkRequest), "\"0\""):
+					close(firstWritten)
+
+					return successfulBulkResponseBody(t, bulkRequest), nil
+				case strings.Contains(string(bulkRequest), "\"1\""):
+					<-firstWritten
+
+					return nil, errors.New("[http] request entity too large")
+				}
+
+				// The other requests are in flight until the workers are stopped
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:    1,
+				BulkWorkers: 2,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		// Records without a key are partitioned by their positions
+		var records []opencdc.Record
+		for i := 0; i < 6; i++ {
+			records = append(records, sdk.SourceUtil{}.NewRecordCreate(nil, nil, nil, opencdc.RawData(strconv.Itoa(i))))
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.EqualError(t, err, "bulk request failure: [http] request entity too large")
+		require.Equal(t, 1, n)
+
+		for _, bulkRequest := range bulkRequests {
+			require.NotContains(t, bulkRequest, "\"3\"")
+			require.NotContains(t, bulkRequest, "\"4\"")
+			require.NotContains(t, bulkRequest, "\"5\"")
+		}
+	})
+
+	t.Run("Skips Documents that already exist in create write mode", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				data, err := json.Marshal(bulkResponseJSON{
+					Errors: true,
+					Items: []bulkResponseItems{
+						{Create: &bulkResponseItem{Status: http.StatusConflict}},
+						{Create: &bulkResponseItem{Status: http.StatusCreated}},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode: api.WriteModeCreate,
+				Retries:   2,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Skips rejected records with the skip error policy", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusBadRequest, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				ErrorPolicy: ErrorPolicySkip,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Fails on retryable failures with the skip error policy", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusTooManyRequests), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				ErrorPolicy: ErrorPolicySkip,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.EqualError(t, err, "item with key= update failure: unknown error status: 429")
+		require.Equal(t, 1, n)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Writes records rejected before a retryable failure to the dead-letter index", func(t *testing.T) {
+		const deadLetterIndex = "dead-letters"
+
+		var deadLetters []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			PrepareCreateOperationFunc: func(item opencdc.Record, index string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return index, item.Payload.After, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				lines := bytes.Split(bytes.TrimSpace(bulkRequest), []byte("\n"))
+				if string(lines[0]) != fmt.Sprintf("%q", deadLetterIndex) {
+					return bulkResponseBody(t, http.StatusOK, http.StatusBadRequest, http.StatusTooManyRequests), nil
+				}
+
+				for i := 1; i < len(lines); i += 2 {
+					var deadLetter struct {
+						Record opencdc.Record `json:"record"`
+					}
+					require.NoError(t, json.Unmarshal(lines[i], &deadLetter))
+					deadLetters = append(deadLetters, string(deadLetter.Record.Position))
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				ErrorPolicy:     ErrorPolicyDeadLetterIndex,
+				DeadLetterIndex: deadLetterIndex,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		records := []opencdc.Record{upsertRecord("1"), upsertRecord("2"), upsertRecord("3")}
+		for i := range records {
+			records[i].Position = opencdc.Position(strconv.Itoa(i))
+		}
+
+		// The rejected record is reported as written, so it's dead-lettered before the write fails
+		n, err := destination.Write(context.Background(), records)
+		require.EqualError(t, err, "item with key= update failure: unknown error status: 429")
+		require.Equal(t, 2, n)
+		require.Equal(t, []string{"1"}, deadLetters)
+		require.Len(t, esClientMock.BulkCalls(), 2)
+	})
+
+	t.Run("Writes rejected records to the dead-letter index", func(t *testing.T) {
+		const deadLetterIndex = "dead-letters"
+
+		var bulkCalls int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			PrepareCreateOperationFunc: func(item opencdc.Record, index string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				require.Equal(t, deadLetterIndex, index)
+
+				return index, item.Payload.After, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkCalls++
+				if bulkCalls == 1 {
+					data, err := json.Marshal(bulkResponseJSON{
+						Errors: true,
+						Items: []bulkResponseItems{
+							{Update: &bulkResponseItem{Status: http.StatusOK}},
+							{Update: &bulkResponseItem{
+								Index:  indexName,
+								ID:     "2",
+								Status: http.StatusBadRequest,
+								Error: &bulkResponseItemError{
+									Type:   "mapper_parsing_exception",
+									Reason: "failed to parse",
+								},
+							}},
+						},
+					})
+					require.NoError(t, err)
+
+					return io.NopCloser(bytes.NewReader(data)), nil
+				}
+
+				lines := bytes.Split(bytes.TrimSpace(bulkRequest), []byte("\n"))
+				require.Len(t, lines, 2)
+				require.Equal(t, fmt.Sprintf("%q", deadLetterIndex), string(lines[0]))
+
+				var deadLetter map[string]interface{}
+				require.NoError(t, json.Unmarshal(lines[1], &deadLetter))
+				require.Equal(t, indexName, deadLetter["index"])
+				require.Equal(t, "2", deadLetter["id"])
+				require.Equal(t, "update", deadLetter["operation"])
+				require.Equal(t, float64(http.StatusBadRequest), deadLetter["status"])
+				require.Equal(t, map[string]interface{}{
+					"type":   "mapper_parsing_exception",
+					"reason": "failed to parse",
+				}, deadLetter["error"])
+				require.Equal(t, "update", deadLetter["record"].(map[string]interface{})["operation"])
+
+				data, err := json.Marshal(bulkResponseJSON{
+					Items: []bulkResponseItems{
+						{Create: &bulkResponseItem{Status: http.StatusCreated}},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				ErrorPolicy:     ErrorPolicyDeadLetterIndex,
+				DeadLetterIndex: deadLetterIndex,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Len(t, esClientMock.BulkCalls(), 2)
+	})
+
+	t.Run("Fails when rejected records can't be written to the dead-letter index", func(t *testing.T) {
+		var bulkCalls int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			PrepareCreateOperationFunc: func(item opencdc.Record, index string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return index, item.Payload.After, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkCalls++
+				if bulkCalls == 1 {
+					return bulkResponseBody(t, http.StatusOK, http.StatusBadRequest), nil
+				}
+
+				return nil, errors.New("[index_not_found_exception] no such index")
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				ErrorPolicy:     ErrorPolicyDeadLetterIndex,
+				DeadLetterIndex: "dead-letters",
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.EqualError(t, err, "dead letter index failure: bulk request failure: [index_not_found_exception] no such index")
+		require.Equal(t, 1, n)
+	})
+
+	t.Run("Fails when the dead-letter index response misses items", func(t *testing.T) {
+		var bulkCalls int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			PrepareCreateOperationFunc: func(item opencdc.Record, index string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return index, item.Payload.After, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkCalls++
+				if bulkCalls == 1 {
+					return bulkResponseBody(t, http.StatusBadRequest, http.StatusBadRequest), nil
+				}
+
+				return bulkResponseBody(t, http.StatusBadRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				ErrorPolicy:     ErrorPolicyDeadLetterIndex,
+				DeadLetterIndex: "dead-letters",
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.EqualError(t, err, "dead letter index failure: expected 2 items, got 1")
+		require.Equal(t, 0, n)
+	})
+
+	t.Run("Passes the external version and skips stale operations", func(t *testing.T) {
+		var versions []int64
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, options api.BulkOperationOptions) (interface{}, interface{}, error) {
+				require.Equal(t, api.VersionTypeExternalGTE, options.VersionType)
+				versions = append(versions, *options.Version)
+
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusConflict, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				VersionType: api.VersionTypeExternalGTE,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			getVersion: func(r opencdc.Record) (int64, error) {
+				return strconv.ParseInt(string(r.Key.Bytes()), 10, 64)
+			},
+			client: &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []int64{1, 2}, versions)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Routes upserts and deletes of the same document alike", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, options api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, options.Routing, nil
+			},
+
+			PrepareDeleteOperationFunc: func(key string, _ string, options api.BulkOperationOptions) (interface{}, error) {
+				return options.Routing, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.Equal(t, "\"1\"\n\"a\"\n\"a\"\n", string(bulkRequest))
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK), nil
+			},
+		}
+
+		routingFn, err := Config{Routing: `{{ .Payload.After.tenant }}`}.RoutingFunction()
+		require.NoError(t, err)
+
+		destination := Destination{
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			getRouting:    routingFn,
+			client:        &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			sdk.SourceUtil{}.NewRecordCreate(nil, nil, opencdc.RawData("1"), opencdc.StructuredData{"tenant": "a"}),
+			sdk.SourceUtil{}.NewRecordDelete(nil, nil, opencdc.RawData("1"), opencdc.StructuredData{"tenant": "a"}),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+	})
+
+	t.Run("Appends records to the data stream", func(t *testing.T) {
+		var timestamps []any
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				payload, err := structuredPayload(item.Payload.After)
+				require.NoError(t, err)
+				timestamps = append(timestamps, payload["@timestamp"])
+
+				return key, key, nil
+			},
+
+			PrepareCreateOperationFunc: func(item opencdc.Record, index string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				if index == indexName {
+					payload, err := structuredPayload(item.Payload.After)
+					require.NoError(t, err)
+					timestamps = append(timestamps, payload["@timestamp"])
+				}
+
+				return index, item.Payload.After, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				DataStream:                  true,
+				DataStreamTimestampMetadata: opencdc.MetadataCreatedAt,
+				DataStreamUpdatePolicy:      DataStreamPolicyAppend,
+				DataStreamDeletePolicy:      DataStreamPolicyDeadLetterIndex,
+				DeadLetterIndex:             "dead-letters",
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		metadata := opencdc.Metadata{}
+		metadata.SetCreatedAt(createdAt)
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			sdk.SourceUtil{}.NewRecordCreate(nil, metadata, opencdc.RawData("1"), opencdc.StructuredData{"id": 1}),
+			sdk.SourceUtil{}.NewRecordUpdate(nil, metadata, opencdc.RawData("1"), nil, opencdc.RawData(`{"id":1,"@timestamp":"2020-01-01"}`)),
+			sdk.SourceUtil{}.NewRecordDelete(nil, metadata, opencdc.RawData("1"), nil),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Equal(t, []any{createdAt.Format(time.RFC3339Nano), "2020-01-01"}, timestamps)
+		require.Len(t, esClientMock.PrepareUpsertOperationCalls(), 1)
+		require.Len(t, esClientMock.PrepareCreateOperationCalls(), 2)
+		require.Len(t, esClientMock.PrepareDeleteOperationCalls(), 0)
+		require.Equal(t, "dead-letters", esClientMock.PrepareCreateOperationCalls()[1].Index)
+	})
+
+	t.Run("Skips or fails on deletes written to the data stream", func(t *testing.T) {
+		esClientMock := clientMock{
+			BulkFunc: func(_ context.Context, _ io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				return bulkResponseBody(t), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				DataStream:             true,
+				DataStreamDeletePolicy: DataStreamPolicySkip,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		records := []opencdc.Record{
+			sdk.SourceUtil{}.NewRecordDelete(nil, nil, opencdc.RawData("1"), nil),
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Len(t, esClientMock.PrepareDeleteOperationCalls(), 0)
+
+		destination.config.DataStreamDeletePolicy = DataStreamPolicyFail
+
+		n, err = destination.Write(context.Background(), records)
+		require.ErrorContains(t, err, "not supported by data streams")
+		require.Equal(t, 0, n)
+	})
+
+	t.Run("Creates each missing index once", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(_ context.Context, index string) (bool, error) {
+				return index == "users-a", nil
+			},
+
+			CreateIndexFunc: func(_ context.Context, _ string, _ api.IndexDefinition) error {
+				return nil
+			},
+
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK, http.StatusOK), nil
+			},
+		}
+
+		definition := api.IndexDefinition{
+			Mappings: json.RawMessage(`{"properties":{"name":{"type":"keyword"}}}`),
+		}
+
+		destination := Destination{
+			getIndexName: func(r opencdc.Record) (string, error) {
+				return "users-" + string(r.Key.Bytes()), nil
+			},
+			getDocumentID:   keyDocumentID(KeyFormatJSON, ""),
+			indexDefinition: definition,
+			client:          &esClientMock,
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := destination.Write(context.Background(), []opencdc.Record{
+				upsertRecord("a"),
+				upsertRecord("b"),
+				upsertRecord("b"),
+			})
+			require.NoError(t, err)
+		}
+
+		require.Len(t, esClientMock.IndexExistsCalls(), 2)
+		require.Len(t, esClientMock.CreateIndexCalls(), 1)
+		require.Equal(t, "users-b", esClientMock.CreateIndexCalls()[0].Index)
+		require.Equal(t, definition, esClientMock.CreateIndexCalls()[0].Definition)
+	})
+
+	t.Run("Applies the delete policy", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareSoftDeleteOperationFunc: func(key string, _ string, doc opencdc.StructuredData, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, doc, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.Equal(t, "\"1\"\n{\"deleted\":true,\"deleted_at\":\"2024-01-02T03:04:05Z\"}\n", string(bulkRequest))
+
+				return bulkResponseBody(t, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				DeletePolicy:        DeletePolicySoft,
+				SoftDeleteField:     "deleted",
+				SoftDeleteTimeField: "deleted_at",
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		metadata := opencdc.Metadata{}
+		metadata.SetCreatedAt(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+		records := []opencdc.Record{
+			sdk.SourceUtil{}.NewRecordDelete(nil, metadata, opencdc.RawData("1"), nil),
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Len(t, esClientMock.PrepareDeleteOperationCalls(), 0)
+
+		destination.config.DeletePolicy = DeletePolicyIgnore
+
+		n, err = destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Updates only the fields changed between before and after", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareDiffUpdateOperationFunc: func(key string, _ string, diff api.DocumentDiff, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, diff, nil
+			},
+
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, "upsert", nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.Equal(t, "\"1\"\n"+
+					`{"Changed":{"name":"Jane"},"Removed":["email"],"Document":{"age":30,"name":"Jane"}}`+"\n"+
+					"\"2\"\n\"upsert\"\n", string(bulkRequest))
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode:               api.WriteModeUpdate,
+				UpdateDiff:              true,
+				UpdateDiffRemovedFields: UpdateDiffRemovedFieldsRemove,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		records := []opencdc.Record{
+			sdk.SourceUtil{}.NewRecordUpdate(nil, nil, opencdc.RawData("1"),
+				opencdc.StructuredData{"name": "John", "age": 30, "email": "john@example.com"},
+				opencdc.StructuredData{"name": "Jane", "age": 30},
+			),
+			sdk.SourceUtil{}.NewRecordUpdate(nil, nil, opencdc.RawData("2"),
+				nil,
+				opencdc.StructuredData{"name": "Jane"},
+			),
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Len(t, esClientMock.PrepareDiffUpdateOperationCalls(), 1)
+		require.Len(t, esClientMock.PrepareUpsertOperationCalls(), 1)
+	})
+
+	t.Run("Passes the templated script params", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, options api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, options.ScriptParams, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.Equal(t, "\"1\"\n{\"count\":5}\n", string(bulkRequest))
+
+				return bulkResponseBody(t, http.StatusOK), nil
+			},
+		}
+
+		config := Config{
+			WriteMode:    api.WriteModeScript,
+			ScriptID:     "increment-counter",
+			ScriptParams: `{"count": {{ index .Payload.After "count" }}}`,
+		}
+		getScriptParams, err := config.ScriptParamsFunction()
+		require.NoError(t, err)
+
+		destination := Destination{
+			config: config,
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID:   keyDocumentID(KeyFormatJSON, ""),
+			getScriptParams: getScriptParams,
+			client:          &esClientMock,
+		}
+
+		records := []opencdc.Record{
+			sdk.SourceUtil{}.NewRecordUpdate(nil, nil, opencdc.RawData("1"), nil, opencdc.StructuredData{"count": 5}),
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	})
+
+	t.Run("Deletes records without a key by query in order", func(t *testing.T) {
+		var calls []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				calls = append(calls, "bulk "+strings.ReplaceAll(string(bulkRequest), "\n", " "))
+
+				return bulkResponseBody(t, http.StatusOK), nil
+			},
+
+			DeleteByQueryFunc: func(_ context.Context, request *api.DeleteByQueryRequest) (int64, error) {
+				require.Equal(t, indexName, request.Index)
+				calls = append(calls, fmt.Sprintf("delete %v", request.Match))
+
+				return 1, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				DeleteByQueryFields: []string{"email"},
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		keylessDelete := sdk.SourceUtil{}.NewRecordDelete(nil, nil, nil, opencdc.StructuredData{"email": "a@example.com"})
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("1"),
+			keylessDelete,
+			upsertRecord("2"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Equal(t, []string{
+			`bulk "1" "1" `,
+			"delete map[email:a@example.com]",
+			`bulk "2" "2" `,
+		}, calls)
+
+		destination.config.DeleteByQueryFields = nil
+
+		n, err = destination.Write(context.Background(), []opencdc.Record{keylessDelete})
+		require.ErrorContains(t, err, `without a key requires "deleteByQueryFields"`)
+		require.Equal(t, 0, n)
+	})
+
+	t.Run("Compacts the operations on the same document", func(t *testing.T) {
+		var calls []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, record opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, string(record.Payload.After.Bytes()), nil
+			},
+
+			PrepareDeleteOperationFunc: func(key string, _ string, _ api.BulkOperationOptions) (interface{}, error) {
+				return "delete " + key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				calls = append(calls, "bulk "+strings.ReplaceAll(string(bulkRequest), "\n", " "))
+
+				return bulkResponseBody(t, http.StatusOK), nil
+			},
+
+			DeleteByQueryFunc: func(_ context.Context, request *api.DeleteByQueryRequest) (int64, error) {
+				calls = append(calls, fmt.Sprintf("delete %v", request.Match))
+
+				return 1, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode:           api.WriteModeIndex,
+				DeleteByQueryFields: []string{"email"},
+				CompactOperations:   true,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		update := func(key, payload string) opencdc.Record {
+			return sdk.SourceUtil{}.NewRecordUpdate(nil, nil, opencdc.RawData(key), nil, opencdc.RawData(payload))
+		}
+		deleteRecord := sdk.SourceUtil{}.NewRecordDelete(nil, nil, opencdc.RawData("1"), nil)
+		keylessDelete := sdk.SourceUtil{}.NewRecordDelete(nil, nil, nil, opencdc.StructuredData{"email": "a@example.com"})
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			update("1", "a"),
+			update("2", "b"),
+			deleteRecord,
+			update("1", "c"),
+			keylessDelete,
+			update("2", "d"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 6, n)
+		require.Equal(t, []string{
+			`bulk "2" "b" "1" "c" `,
+			"delete map[email:a@example.com]",
+			`bulk "2" "d" `,
+		}, calls)
+
+		// The updates merge into the document, so only the delete supersedes the earlier update
+		calls = nil
+		destination.config.WriteMode = api.WriteModeUpdate
+
+		n, err = destination.Write(context.Background(), []opencdc.Record{
+			update("1", "a"),
+			deleteRecord,
+			update("1", "b"),
+			update("1", "c"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, n)
+		require.Equal(t, []string{`bulk "delete 1" "1" "b" "1" "c" `}, calls)
+	})
+
+	t.Run("Writes records superseded by a rejected operation to the dead-letter index", func(t *testing.T) {
+		const deadLetterIndex = "dead-letters"
+
+		var deadLetters []string
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			PrepareCreateOperationFunc: func(item opencdc.Record, index string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return index, item.Payload.After, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				lines := bytes.Split(bytes.TrimSpace(bulkRequest), []byte("\n"))
+				if string(lines[0]) != fmt.Sprintf("%q", deadLetterIndex) {
+					require.Len(t, lines, 4)
+
+					return bulkResponseBody(t, http.StatusOK, http.StatusBadRequest), nil
+				}
+
+				for i := 1; i < len(lines); i += 2 {
+					var deadLetter struct {
+						Record opencdc.Record `json:"record"`
+					}
+					require.NoError(t, json.Unmarshal(lines[i], &deadLetter))
+					deadLetters = append(deadLetters, string(deadLetter.Record.Position))
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode:         api.WriteModeIndex,
+				CompactOperations: true,
+				ErrorPolicy:       ErrorPolicyDeadLetterIndex,
+				DeadLetterIndex:   deadLetterIndex,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		records := []opencdc.Record{upsertRecord("2"), upsertRecord("1"), upsertRecord("2")}
+		for i := range records {
+			records[i].Position = opencdc.Position(strconv.Itoa(i))
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Equal(t, []string{"0", "2"}, deadLetters)
+	})
+
+	t.Run("Reports records superseded by a rejected operation as unwritten when dead-lettering fails", func(t *testing.T) {
+		var bulkCalls int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			PrepareCreateOperationFunc: func(item opencdc.Record, index string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return index, item.Payload.After, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkCalls++
+				if bulkCalls == 1 {
+					return bulkResponseBody(t, http.StatusOK, http.StatusBadRequest), nil
+				}
+
+				return nil, errors.New("[index_not_found_exception] no such index")
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode:         api.WriteModeIndex,
+				CompactOperations: true,
+				ErrorPolicy:       ErrorPolicyDeadLetterIndex,
+				DeadLetterIndex:   "dead-letters",
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			client:        &esClientMock,
+		}
+
+		// The first record is superseded by the rejected third one
+		n, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("2"),
+			upsertRecord("1"),
+			upsertRecord("2"),
+		})
+		require.EqualError(t, err, "dead letter index failure: bulk request failure: [index_not_found_exception] no such index")
+		require.Equal(t, 0, n)
+	})
+
+	t.Run("Shrinks the bulk requests when items are rejected by the overloaded cluster", func(t *testing.T) {
+		var bulkRequests []int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				bulkRequests = append(bulkRequests, bytes.Count(bulkRequest, []byte("\n"))/2)
+
+				if len(bulkRequests) == 1 {
+					return bulkResponseBody(t, http.StatusTooManyRequests, http.StatusOK, http.StatusOK, http.StatusOK), nil
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:      4,
+				Retries:       1,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			bulkSizer:     newAdaptiveBulkSizer(1, 4, time.Minute, adaptiveBulkMinDelay),
+			client:        &esClientMock,
+		}
+
+		records := make([]opencdc.Record, 8)
+		for i := range records {
+			records[i] = upsertRecord(strconv.Itoa(i + 1))
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 8, n)
+		// The rejected item is retried, then the halved requests grow back once they succeed
+		require.Equal(t, []int{4, 1, 2, 2}, bulkRequests)
+	})
+
+	t.Run("Shrinks the bulk requests when the whole request is rejected by the overloaded cluster", func(t *testing.T) {
+		var bulkRequests []int
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				bulkRequests = append(bulkRequests, bytes.Count(bulkRequest, []byte("\n"))/2)
+
+				if len(bulkRequests) == 1 {
+					return nil, &api.BulkRequestError{
+						StatusCode: http.StatusTooManyRequests,
+						Err:        errors.New("too many requests"),
+					}
+				}
+
+				return successfulBulkResponseBody(t, bulkRequest), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:      4,
+				Retries:       1,
+				RetryMinDelay: time.Millisecond,
+				RetryMaxDelay: time.Millisecond,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			bulkSizer:     newAdaptiveBulkSizer(1, 4, time.Minute, adaptiveBulkMinDelay),
+			client:        &esClientMock,
+		}
+
+		records := make([]opencdc.Record, 8)
+		for i := range records {
+			records[i] = upsertRecord(strconv.Itoa(i + 1))
+		}
+
+		n, err := destination.Write(context.Background(), records)
+		require.NoError(t, err)
+		require.Equal(t, 8, n)
+		// The rejected request is retried as a whole, then the halved requests grow back once they succeed
+		require.Equal(t, []int{4, 4, 3, 1}, bulkRequests)
+	})
+
+	t.Run("Bootstraps missing rollover aliases", func(t *testing.T) {
+		esClientMock := clientMock{
+			AliasExistsFunc: func(_ context.Context, alias string) (bool, error) {
+				return alias == "logs-a", nil
+			},
+
+			IndexExistsFunc: func(_ context.Context, _ string) (bool, error) {
+				return false, nil
+			},
+
+			CreateIndexFunc: func(_ context.Context, _ string, _ api.IndexDefinition) error {
+				return nil
+			},
+
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Rollover:  true,
+				ILMPolicy: "logs-policy",
+			},
+			getIndexName: func(r opencdc.Record) (string, error) {
+				return "logs-" + string(r.Key.Bytes()), nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			indexDefinition: api.IndexDefinition{
+				Settings: json.RawMessage(`{"number_of_shards":1}`),
+			},
+			client: &esClientMock,
+		}
+
+		_, err := destination.Write(context.Background(), []opencdc.Record{
+			upsertRecord("a"),
+			upsertRecord("b"),
+			upsertRecord("b"),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, esClientMock.AliasExistsCalls(), 2)
+		require.Len(t, esClientMock.CreateIndexCalls(), 1)
+
+		createIndexCall := esClientMock.CreateIndexCalls()[0]
+		require.Equal(t, "logs-b-000001", createIndexCall.Index)
+		require.Equal(t, "logs-b", createIndexCall.Definition.WriteAlias)
+		require.JSONEq(t, `{
+			"number_of_shards": 1,
+			"index.lifecycle.name": "logs-policy",
+			"index.lifecycle.rollover_alias": "logs-b"
+		}`, string(createIndexCall.Definition.Settings))
+	})
+
+	t.Run("Fails when the initial rollover index exists without the alias", func(t *testing.T) {
+		esClientMock := clientMock{
+			AliasExistsFunc: func(_ context.Context, _ string) (bool, error) {
+				return false, nil
+			},
+
+			IndexExistsFunc: func(_ context.Context, _ string) (bool, error) {
+				return true, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Rollover:  true,
+				ILMPolicy: "logs-policy",
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return "logs", nil
+			},
+			client: &esClientMock,
+		}
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{upsertRecord("a")})
+		require.EqualError(t, err, `index "logs-000001" exists without the write alias "logs"`)
+		require.Equal(t, 0, n)
+	})
+
+	t.Run("Puts the mappings of each payload schema version once", func(t *testing.T) {
+		var schemaCalls int
+
+		esClientMock := clientMock{
+			IndexExistsFunc: func(_ context.Context, _ string) (bool, error) {
+				return true, nil
+			},
+
+			PutMappingFunc: func(_ context.Context, _ string, _ json.RawMessage) error {
+				return nil
+			},
+
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusOK, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				SchemaMappings:   true,
+				SchemaStringType: SchemaStringTypeKeyword,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			getSchema: func(_ context.Context, subject string, version int) (schema.Schema, error) {
+				schemaCalls++
+
+				return schema.Schema{
+					Subject: subject,
+					Version: version,
+					Type:    schema.TypeAvro,
+					Bytes:   []byte(`{"type":"record","name":"user","fields":[{"name":"name","type":"string"}]}`),
+				}, nil
+			},
+			client: &esClientMock,
+		}
+
+		records := []opencdc.Record{upsertRecord("1"), upsertRecord("2")}
+		for _, record := range records {
+			record.Metadata.SetPayloadSchemaSubject("users")
+			record.Metadata.SetPayloadSchemaVersion(1)
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := destination.Write(context.Background(), records)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 1, schemaCalls)
+		require.Len(t, esClientMock.PutMappingCalls(), 1)
+		require.JSONEq(t, `{"properties":{"name":{"type":"keyword"}}}`, string(esClientMock.PutMappingCalls()[0].Mappings))
+	})
+
+	t.Run("Maps the fields of unsupported schema types dynamically", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, _ opencdc.Record, _ string, _ api.BulkOperationOptions) (interface{}, interface{}, error) {
+				return key, key, nil
+			},
+
+			BulkFunc: func(_ context.Context, reader io.Reader, _ api.BulkRequestOptions) (io.ReadCloser, error) {
+				_, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				return bulkResponseBody(t, http.StatusOK), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				SchemaMappings: true,
+			},
+			getIndexName: func(_ opencdc.Record) (string, error) {
+				return indexName, nil
+			},
+			getDocumentID: keyDocumentID(KeyFormatJSON, ""),
+			getSchema: func(_ context.Context, subject string, version int) (schema.Schema, error) {
+				return schema.Schema{
+					Subject: subject,
+					Version: version,
+					Type:    schema.TypeAvro + 1,
+					Bytes:   []byte(`{"type":"object","properties":{"name":{"type":"string"}}}`),
+				}, nil
+			},
+			client: &esClientMock,
+		}
+
+		record := upsertRecord("1")
+		record.Metadata.SetPayloadSchemaSubject("users")
+		record.Metadata.SetPayloadSchemaVersion(1)
+
+		n, err := destination.Write(context.Background(), []opencdc.Record{record})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Len(t, esClientMock.PutMappingCalls(), 0)
+		require.Len(t, esClientMock.CreateIndexCalls(), 0)
+	})
+}
+
+func TestDestination_CheckPipeline(t *testing.T) {
+	t.Run("Checks the static pipeline exists", func(t *testing.T) {
+		esClientMock := clientMock{
+			PipelineExistsFunc: func(_ context.Context, name string) (bool, error) {
+				return name == "geoip", nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode: api.WriteModeIndex,
+				Pipeline:  "geoip",
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.checkPipeline(context.Background()))
+
+		destination.config.Pipeline = "missing"
+		require.EqualError(t, destination.checkPipeline(context.Background()), `ingest pipeline "missing" does not exist`)
+		require.Len(t, esClientMock.PipelineExistsCalls(), 2)
+	})
+
+	t.Run("Fails when the pipeline can't be checked", func(t *testing.T) {
+		esClientMock := clientMock{
+			PipelineExistsFunc: func(_ context.Context, _ string) (bool, error) {
+				return false, errors.New("[security_exception] action is unauthorized")
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				WriteMode: api.WriteModeIndex,
+				Pipeline:  "geoip",
+			},
+			client: &esClientMock,
+		}
+
+		require.EqualError(
+			t,
+			destination.checkPipeline(context.Background()),
+			"failed to check ingest pipeline: [security_exception] action is unauthorized",
+		)
+	})
+
+	t.Run("Does not check templated pipelines", func(t *testing.T) {
+		esClientMock := clientMock{}
+
+		destination := Destination{
+			config: Config{
+				WriteMode: api.WriteModeIndex,
+				Pipeline:  `{{ index .Metadata "opencdc.collection" }}-pipeline`,
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.checkPipeline(context.Background()))
+		require.Len(t, esClientMock.PipelineExistsCalls(), 0)
+	})
+}
+
+func TestDiffPayloads(t *testing.T) {
+	before := opencdc.StructuredData{
+		"name":    "John",
+		"age":     30,
+		"email":   "john@example.com",
+		"address": map[string]interface{}{"city": "Paris"},
+	}
+	after := opencdc.StructuredData{
+		"name":    "John",
+		"age":     31,
+		"address": map[string]interface{}{"city": "Paris"},
+		"phone":   "123",
+	}
+
+	require.Equal(t, api.DocumentDiff{
+		Changed: map[string]interface{}{"age": 31, "phone": "123"},
+		Removed: []string{"email"},
+	}, diffPayloads(before, after, false))
+
+	require.Equal(t, api.DocumentDiff{
+		Changed: map[string]interface{}{"age": 31, "phone": "123", "email": nil},
+	}, diffPayloads(before, after, true))
+}
+
+// bulkResponseJSON is the Bulk API response as it's returned by Elasticsearch.
+type bulkResponseJSON struct {
+	Took   int                 `json:"took"`
+	Errors bool                `json:"errors"`
+	Items  []bulkResponseItems `json:"items"`
+}
+
+// upsertRecord returns an update Record with the given key.
+func upsertRecord(key string) opencdc.Record {
+	return sdk.SourceUtil{}.NewRecordUpdate(
+		nil,
+		nil,
+		opencdc.RawData(key),
+		nil,
+		opencdc.RawData(key),
+	)
+}
+
+// bulkResponseBody returns the Bulk API response containing update results with the given statuses.
+func bulkResponseBody(t *testing.T, statuses ...int) io.ReadCloser {
+	response := bulkResponseJSON{}
+	for _, status := range statuses {
+		response.Errors = response.Errors || status >= 300
+		response.Items = append(response.Items, bulkResponseItems{
+			Update: &bulkResponseItem{
+				Status: status,
+			},
+		})
+	}
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	return io.NopCloser(bytes.NewReader(data))
+}
+
+// successfulBulkResponseBody returns the Bulk API response with successful results of all the updates in the request.
+func successfulBulkResponseBody(t *testing.T, bulkRequest []byte) io.ReadCloser {
+	statuses := make([]int, bytes.Count(bulkRequest, []byte("\n"))/2)
+	for i := range statuses {
+		statuses[i] = http.StatusOK
+	}
+
+	return bulkResponseBody(t, statuses...)
+}
+
+func TestAdaptiveBulkSizer(t *testing.T) {
+	ctx := context.Background()
+	sizer := newAdaptiveBulkSizer(10, 100, time.Second, 40*time.Millisecond)
+
+	steps := []struct {
+		name     string
+		items    int
+		rejected int
+		latency  time.Duration
+		size     uint64
+		delay    time.Duration
+	}{
+		{name: "keeps the maximum size", items: 100, latency: time.Millisecond, size: 100},
+		{name: "halves the size and paces the requests on rejections", items: 100, rejected: 1, latency: time.Millisecond, size: 50, delay: 10 * time.Millisecond},
+		{name: "doubles the pause on further rejections", items: 50, rejected: 50, latency: time.Millisecond, size: 25, delay: 20 * time.Millisecond},
+		{name: "shrinks the size on high latency", items: 25, latency: 2 * time.Second, size: 18, delay: 20 * time.Millisecond},
+		{name: "respects the minimum size and the maximum pause", items: 18, rejected: 1, latency: time.Millisecond, size: 10, delay: 40 * time.Millisecond},
+		{name: "keeps the size of requests not using the whole limit", items: 5, latency: time.Millisecond, size: 10, delay: 20 * time.Millisecond},
+		{name: "grows the size of full requests", items: 10, latency: time.Millisecond, size: 12, delay: 10 * time.Millisecond},
+		{name: "drops short pauses", items: 12, latency: time.Millisecond, size: 15},
+	}
+
+	for _, step := range steps {
+		sizer.observe(ctx, step.items, step.rejected, step.latency)
+
+		size, delay := sizer.limits()
+		require.Equal(t, step.size, size, step.name)
+		require.Equal(t, step.delay, delay, step.name)
+	}
+}
+
+func TestBulkRequestBody(t *testing.T) {
+	data := []byte("\"1\"\n\"1\"\n\"2\"\n\"2\"\n\"3\"\n\"3\"\n")
+	items := []bulkItem{
+		{record: 0, data: data[0:8]},
+		{record: 1, data: data[8:16]},
+		{record: 2, data: data[16:24]},
+	}
+
+	t.Run("Uses the data of adjacent items without copying", func(t *testing.T) {
+		body, release := bulkRequestBody(items[1:])
+		defer release()
+
+		require.Equal(t, string(data[8:]), string(body))
+		require.Same(t, &data[8], &body[0])
+	})
+
+	t.Run("Copies the data of separated items", func(t *testing.T) {
+		body, release := bulkRequestBody([]bulkItem{items[0], items[2]})
+		defer release()
+
+		require.Equal(t, "\"1\"\n\"1\"\n\"3\"\n\"3\"\n", string(body))
+	})
+}
+
+func TestDestination_HandleBulkResponse(t *testing.T) {
+	missingDocumentResponse := bulkResponse{
+		Errors: true,
+		Items:  2,
+		Failures: []bulkResponseFailure{
+			{
+				position: 1,
+				item: bulkResponseItems{Update: &bulkResponseItem{
+					ID:     "2",
+					Status: http.StatusNotFound,
+					Error: &bulkResponseItemError{
+						Type:   "document_missing_exception",
+						Reason: "document missing",
+					},
+				}},
+			},
+		},
+	}
+
+	t.Run("Fails on an update of a missing document without script upsert", func(t *testing.T) {
+		destination := Destination{
+			config: Config{
+				WriteMode:    api.WriteModeScript,
+				Script:       "ctx._source.counter += params.doc.counter",
+				ScriptUpsert: api.ScriptUpsertNone,
+			},
+		}
+
+		items := []bulkItem{{record: 0, docKey: "idx/1"}, {record: 1, docKey: "idx/2"}}
+
+		retry, rejected, n, err := destination.handleBulkResponse(context.Background(), items, missingDocumentResponse, true)
+		require.EqualError(t, err, "item with key=2 update failure: [document_missing_exception] document missing: null")
+		require.Equal(t, 1, n)
+		require.Empty(t, retry)
+		require.Empty(t, rejected)
+	})
+
+	t.Run("Rejects an update of a missing document by the error policy", func(t *testing.T) {
+		destination := Destination{
+			config: Config{
+				WriteMode:    api.WriteModeScript,
+				Script:       "ctx._source.counter += params.doc.counter",
+				ScriptUpsert: api.ScriptUpsertNone,
+				ErrorPolicy:  ErrorPolicySkip,
+			},
+		}
+
+		items := []bulkItem{{record: 0, docKey: "idx/1"}, {record: 1, docKey: "idx/2"}}
+
+		retry, rejected, _, err := destination.handleBulkResponse(context.Background(), items, missingDocumentResponse, true)
+		require.NoError(t, err)
+		require.Empty(t, retry)
+		require.Len(t, rejected, 1)
+		require.Equal(t, 1, rejected[0].item.record)
+	})
+
+	t.Run("Ignores deletes of missing documents", func(t *testing.T) {
+		destination := Destination{}
+
+		items := []bulkItem{{record: 0, docKey: "idx/1"}, {record: 1, docKey: "idx/2", deletes: true}}
+
+		retry, rejected, _, err := destination.handleBulkResponse(context.Background(), items, bulkResponse{
+			Errors: true,
+			Items:  2,
+			Failures: []bulkResponseFailure{
+				{
+					position: 1,
+					item:     bulkResponseItems{Delete: &bulkResponseItem{ID: "2", Status: http.StatusNotFound}},
+				},
+			},
+		}, true)
+		require.NoError(t, err)
+		require.Empty(t, retry)
+		require.Empty(t, rejected)
+	})
+}
+
+func TestDecodeBulkResponse(t *testing.T) {
+	t.Run("Does not decode the items when there are no errors", func(t *testing.T) {
+		response, err := decodeBulkResponse(strings.NewReader(`{"errors":false,"items":[not decoded]}`))
+		require.NoError(t, err)
+		require.Equal(t, bulkResponse{}, response)
+	})
+
+	t.Run("Keeps the failed items along with their positions", func(t *testing.T) {
+		response, err := decodeBulkResponse(strings.NewReader(`{"took":3,"errors":true,"items":[` +
+			`{"update":{"_id":"1","status":200}},` +
+			`{"update":{"_id":"2","status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}},` +
+			`{"delete":{"_id":"3","status":404}},` +
+			`{"create":{"_id":"4","status":409}}` +
+			`]}`))
+		require.NoError(t, err)
+		require.Equal(t, bulkResponse{
+			Errors: true,
+			Items:  4,
+			Failures: []bulkResponseFailure{
+				{
+					position: 1,
+					item: bulkResponseItems{Update: &bulkResponseItem{
+						ID:     "2",
+						Status: http.StatusTooManyRequests,
+						Error: &bulkResponseItemError{
+							Type:   "es_rejected_execution_exception",
+							Reason: "queue full",
+						},
+					}},
+				},
+				{
+					position: 2,
+					item:     bulkResponseItems{Delete: &bulkResponseItem{ID: "3", Status: http.StatusNotFound}},
+				},
+				{
+					position: 3,
+					item:     bulkResponseItems{Create: &bulkResponseItem{ID: "4", Status: http.StatusConflict}},
+				},
+			},
+		}, response)
+	})
+
+	t.Run("Fails on a malformed response", func(t *testing.T) {
+		_, err := decodeBulkResponse(strings.NewReader(`[]`))
+		require.EqualError(t, err, "expected {, got [")
+	})
+}
+
+func TestSchemaToMappings(t *testing.T) {
+	mappings, err := schemaToMappings(schema.Schema{
+		Type: schema.TypeAvro,
+		Bytes: []byte(`{
+			"type": "record",
+			"name": "user",
+			"fields": [
+				{"name": "id", "type": "long"},
+				{"name": "age", "type": "int"},
+				{"name": "score", "type": ["null", "double"]},
+				{"name": "active", "type": "boolean"},
+				{"name": "bio", "type": "string"},
+				{"name": "createdAt", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+				{"name": "status", "type": {"type": "enum", "name": "status", "symbols": ["ACTIVE", "BLOCKED"]}},
+				{"name": "address", "type": {"type": "record", "name": "address", "fields": [
+					{"name": "city", "type": "string"}
+				]}},
+				{"name": "orders", "type": {"type": "array", "items": {"type": "record", "name": "order", "fields": [
+					{"name": "total", "type": "float"}
+				]}}},
+				{"name": "tags", "type": {"type": "array", "items": "string"}},
+				{"name": "mixed", "type": ["string", "long"]}
+			]
+		}`),
+	}, SchemaStringTypeText)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"properties": {
+		"id": {"type": "long"},
+		"age": {"type": "integer"},
+		"score": {"type": "double"},
+		"active": {"type": "boolean"},
+		"bio": {"type": "text"},
+		"createdAt": {"type": "date"},
+		"status": {"type": "keyword"},
+		"address": {"type": "object", "properties": {"city": {"type": "text"}}},
+		"orders": {"type": "nested", "properties": {"total": {"type": "float"}}},
+		"tags": {"type": "text"}
+	}}`, string(mappings))
 }
@@ -0,0 +1,199 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// Formats of the Document ID derived from the Record's Key.
+const (
+	// KeyFormatJSON uses the Key as it is, i.e. StructuredData is encoded as JSON.
+	KeyFormatJSON = "json"
+	// KeyFormatJoin joins the values of the StructuredData fields sorted by their names.
+	KeyFormatJoin = "join"
+	// KeyFormatHash uses the hex encoded SHA-256 hash of the Key.
+	KeyFormatHash = "hash"
+)
+
+// Strategies of deriving the Document ID of records without a key.
+const (
+	// KeylessIDNone lets Elasticsearch generate the Document ID.
+	KeylessIDNone = "none"
+	// KeylessIDPosition uses the hash of the record's collection and position.
+	KeylessIDPosition = "position"
+	// KeylessIDPayload uses the hash of the selected payload fields.
+	KeylessIDPayload = "payload"
+)
+
+type IDFn func(opencdc.Record) (string, error)
+
+// keyDocumentID returns a function that derives the Document ID from the Record's Key in the given format.
+func keyDocumentID(format, separator string) IDFn {
+	return func(r opencdc.Record) (string, error) {
+		if r.Key == nil {
+			return "", nil
+		}
+
+		switch format {
+		case KeyFormatJoin:
+			return joinKey(r.Key, separator)
+
+		case KeyFormatHash:
+			if len(r.Key.Bytes()) == 0 {
+				return "", nil
+			}
+
+			sum := sha256.Sum256(r.Key.Bytes())
+
+			return hex.EncodeToString(sum[:]), nil
+
+		default:
+			return string(r.Key.Bytes()), nil
+		}
+	}
+}
+
+// keylessDocumentID returns a function that derives a deterministic Document ID of a record without a key,
+// so the record written again overwrites the same Document. An empty ID is returned when it can't be derived.
+func keylessDocumentID(strategy string, fields []string) IDFn {
+	return func(r opencdc.Record) (string, error) {
+		switch strategy {
+		case KeylessIDPosition:
+			// Deletes can't be matched with the position of the record that created the Document
+			if r.Operation == opencdc.OperationDelete || len(r.Position) == 0 {
+				return "", nil
+			}
+
+			collection, _ := r.Metadata.GetCollection()
+
+			return hashID([]byte(collection), r.Position), nil
+
+		case KeylessIDPayload:
+			// Deletes carry the deleted Document's data in the payload before the change
+			payload := r.Payload.After
+			if r.Operation == opencdc.OperationDelete {
+				payload = r.Payload.Before
+			}
+
+			if payload == nil || len(payload.Bytes()) == 0 {
+				return "", nil
+			}
+
+			if len(fields) == 0 {
+				return hashID(payload.Bytes()), nil
+			}
+
+			selected, err := selectFields(payload, fields)
+			if err != nil {
+				return "", fmt.Errorf("failed to select payload fields: %w", err)
+			}
+
+			return hashID(selected), nil
+
+		default:
+			return "", nil
+		}
+	}
+}
+
+// selectFields returns the JSON encoded object containing only the given fields of the payload.
+func selectFields(payload opencdc.Data, fields []string) ([]byte, error) {
+	structuredPayload, ok := payload.(opencdc.StructuredData)
+	if !ok {
+		if err := json.Unmarshal(payload.Bytes(), &structuredPayload); err != nil {
+			return nil, err
+		}
+	}
+
+	selected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		selected[field] = structuredPayload[field]
+	}
+
+	// Map keys are sorted when encoded, so the result doesn't depend on the order of fields
+	return json.Marshal(selected)
+}
+
+// hashID returns the hex encoded SHA-256 hash of the given parts.
+func hashID(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		// Parts are separated, so that moving bytes between them changes the hash
+		hash.Write([]byte{0})
+		hash.Write(part)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// joinKey joins the values of the structured Key fields sorted by their names.
+// Raw Keys are returned as they are.
+func joinKey(key opencdc.Data, separator string) (string, error) {
+	structuredKey, ok := key.(opencdc.StructuredData)
+	if !ok {
+		return string(key.Bytes()), nil
+	}
+
+	fields := make([]string, 0, len(structuredKey))
+	for field := range structuredKey {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		value, err := formatKeyValue(structuredKey[field])
+		if err != nil {
+			return "", fmt.Errorf("failed to format key field %q: %w", field, err)
+		}
+
+		values[i] = value
+	}
+
+	return strings.Join(values, separator), nil
+}
+
+// formatKeyValue returns the textual representation of the Key field value.
+func formatKeyValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+
+	case string:
+		return v, nil
+
+	case []byte:
+		return string(v), nil
+
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
+
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		return string(encoded), nil
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// Policies of handling records rejected by Elasticsearch.
+const (
+	// ErrorPolicyFail stops writing at the first rejected record.
+	ErrorPolicyFail = "fail"
+	// ErrorPolicySkip logs the rejected records and continues with the rest of the batch.
+	ErrorPolicySkip = "skip"
+	// ErrorPolicyDeadLetterIndex writes the rejected records to the dead-letter index
+	// and continues with the rest of the batch.
+	ErrorPolicyDeadLetterIndex = "deadLetterIndex"
+)
+
+// rejectedItem is a bulk item that failed permanently. Items failing with a retryable status are never rejected.
+type rejectedItem struct {
+	item          bulkItem
+	response      bulkResponseItem
+	operationType string
+}
+
+// tolerateRejections reports whether rejected items are handled without failing the write.
+func (d *Destination) tolerateRejections() bool {
+	return d.config.ErrorPolicy == ErrorPolicySkip || d.config.ErrorPolicy == ErrorPolicyDeadLetterIndex
+}
+
+// sortRejectedItems orders the rejected items by their records.
+func sortRejectedItems(rejected []rejectedItem) []rejectedItem {
+	sort.Slice(rejected, func(i, j int) bool {
+		return rejected[i].item.record < rejected[j].item.record
+	})
+
+	return rejected
+}
+
+// rejectedBefore returns the rejected items of the records preceding the given position.
+// The later records aren't reported as written, so they are redelivered instead.
+func rejectedBefore(rejected []rejectedItem, position int) []rejectedItem {
+	n := sort.Search(len(rejected), func(i int) bool {
+		return rejected[i].item.record >= position
+	})
+
+	return rejected[:n]
+}
+
+// firstRejectedRecord returns the position of the earliest record of the rejected items,
+// including the records superseded by their operations.
+func firstRejectedRecord(rejected []rejectedItem) int {
+	first := rejected[0].item.record
+	for _, r := range rejected {
+		if len(r.item.superseded) > 0 {
+			first = min(first, r.item.superseded[0])
+		}
+	}
+
+	return first
+}
+
+// handleRejectedItems applies the error policy to the rejected items.
+func (d *Destination) handleRejectedItems(ctx context.Context, records []opencdc.Record, rejected []rejectedItem) error {
+	for _, r := range rejected {
+		sdk.Logger(ctx).Warn().
+			Err(r.response.err(r.operationType)).
+			Int("record", r.item.record).
+			Ints("superseded", r.item.superseded).
+			Str("policy", d.config.ErrorPolicy).
+			Msg("record rejected by Elasticsearch")
+	}
+
+	if d.config.ErrorPolicy != ErrorPolicyDeadLetterIndex || len(rejected) == 0 {
+		return nil
+	}
+
+	return d.writeDeadLetters(ctx, records, rejected)
+}
+
+// writeDeadLetters writes the rejected records along with the Elasticsearch errors to the dead-letter index.
+func (d *Destination) writeDeadLetters(ctx context.Context, records []opencdc.Record, rejected []rejectedItem) error {
+	data := getBulkBuffer()
+	defer putBulkBuffer(data)
+
+	items := make([]bulkItem, 0, len(rejected))
+	offsets := make([]int, 1, len(rejected)+1)
+
+	for _, r := range rejected {
+		// The records superseded by the rejected operation weren't written either
+		positions := make([]int, 0, len(r.item.superseded)+1)
+		positions = append(append(positions, r.item.superseded...), r.item.record)
+
+		for _, position := range positions {
+			deadLetter, err := newDeadLetter(records[position], r)
+			if err != nil {
+				return err
+			}
+
+			if err := d.writeInsertOperation(data, deadLetter, d.config.DeadLetterIndex, api.BulkOperationOptions{}); err != nil {
+				return fmt.Errorf("failed to prepare dead letter: %w", err)
+			}
+
+			items = append(items, bulkItem{record: position})
+			offsets = append(offsets, data.Len())
+		}
+	}
+
+	// The buffer might have been reallocated while growing, so the items are sliced once it's complete
+	for i := range items {
+		items[i].data = data.Bytes()[offsets[i]:offsets[i+1]]
+	}
+
+	response, err := d.executeBulkRequest(ctx, items)
+	if err != nil {
+		return fmt.Errorf("dead letter index failure: %w", err)
+	}
+
+	if response.Errors && response.Items != len(items) {
+		return fmt.Errorf("dead letter index failure: expected %d items, got %d", len(items), response.Items)
+	}
+
+	for _, failure := range response.Failures {
+		itemResponse, operationType := failure.item.result()
+		if itemResponse != nil && !itemResponse.succeeded() {
+			return fmt.Errorf("dead letter index failure: %w", itemResponse.err(operationType))
+		}
+	}
+
+	return nil
+}
+
+// newDeadLetter returns the record describing the rejected record and the reason of its rejection.
+func newDeadLetter(record opencdc.Record, r rejectedItem) (opencdc.Record, error) {
+	encodedRecord, err := json.Marshal(record)
+	if err != nil {
+		return opencdc.Record{}, fmt.Errorf("failed to encode rejected record: %w", err)
+	}
+
+	deadLetter := opencdc.StructuredData{
+		"record":    json.RawMessage(encodedRecord),
+		"index":     r.response.Index,
+		"id":        r.response.ID,
+		"operation": r.operationType,
+		"status":    r.response.Status,
+		"failedAt":  time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	if r.response.Error != nil {
+		deadLetter["error"] = r.response.Error
+	}
+
+	return opencdc.Record{
+		Operation: opencdc.OperationCreate,
+		Metadata:  opencdc.Metadata{},
+		Payload: opencdc.Change{
+			After: deadLetter,
+		},
+	}, nil
+}
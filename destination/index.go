@@ -0,0 +1,200 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// createsIndices reports whether the connector creates the missing indices with the index definition.
+func (d *Destination) createsIndices() bool {
+	if d.config.Rollover {
+		return true
+	}
+
+	if d.config.IndexTemplate != "" {
+		return false
+	}
+
+	return len(d.indexDefinition.Mappings) > 0 || len(d.indexDefinition.Settings) > 0
+}
+
+// ensureTemplate creates the template holding the index definition when it's missing.
+func (d *Destination) ensureTemplate(ctx context.Context) error {
+	if d.config.IndexTemplate == "" {
+		return nil
+	}
+
+	template := api.Template{
+		Name:          d.config.IndexTemplate,
+		Type:          d.config.IndexTemplateType,
+		IndexPatterns: d.config.IndexTemplatePatterns,
+		DataStream:    d.config.DataStream,
+		Definition:    d.indexDefinition,
+	}
+
+	exists, err := d.client.TemplateExists(ctx, template)
+	if err != nil {
+		return fmt.Errorf("failed to check template %q: %w", template.Name, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := d.client.PutTemplate(ctx, template); err != nil {
+		return fmt.Errorf("failed to create template %q: %w", template.Name, err)
+	}
+
+	sdk.Logger(ctx).Info().
+		Str("template", template.Name).
+		Msg("template created")
+
+	return nil
+}
+
+// ensureIndices creates the missing indices the records are written to.
+func (d *Destination) ensureIndices(ctx context.Context, records []opencdc.Record) error {
+	if !d.createsIndices() {
+		return nil
+	}
+
+	for _, record := range records {
+		index, err := d.getIndexName(record)
+		if err != nil {
+			return err
+		}
+
+		if err := d.ensureIndex(ctx, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureIndex creates the index with the index definition when it's missing.
+// In the rollover mode the index is the alias bootstrapped with the initial index.
+// The ensured indices are cached, so each of them is checked only once.
+func (d *Destination) ensureIndex(ctx context.Context, index string) error {
+	if _, ok := d.ensuredIndices[index]; ok {
+		return nil
+	}
+
+	ensure := d.createIndex
+	if d.config.Rollover {
+		ensure = d.bootstrapRolloverAlias
+	}
+
+	if err := ensure(ctx, index); err != nil {
+		return err
+	}
+
+	if d.ensuredIndices == nil {
+		d.ensuredIndices = make(map[string]struct{})
+	}
+	d.ensuredIndices[index] = struct{}{}
+
+	return nil
+}
+
+// createIndex creates the index with the index definition when it's missing.
+func (d *Destination) createIndex(ctx context.Context, index string) error {
+	exists, err := d.client.IndexExists(ctx, index)
+	if err != nil {
+		return fmt.Errorf("failed to check index %q: %w", index, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := d.client.CreateIndex(ctx, index, d.indexDefinition); err != nil {
+		return fmt.Errorf("failed to create index %q: %w", index, err)
+	}
+
+	sdk.Logger(ctx).Info().
+		Str("index", index).
+		Msg("index created")
+
+	return nil
+}
+
+// bootstrapRolloverAlias creates the initial index managed by the ILM policy as the write index of the alias,
+// when the alias is missing.
+func (d *Destination) bootstrapRolloverAlias(ctx context.Context, alias string) error {
+	exists, err := d.client.AliasExists(ctx, alias)
+	if err != nil {
+		return fmt.Errorf("failed to check alias %q: %w", alias, err)
+	}
+	if exists {
+		return nil
+	}
+
+	index := alias + "-000001"
+
+	// An index without the alias would be silently written to instead of being rolled over
+	exists, err = d.client.IndexExists(ctx, index)
+	if err != nil {
+		return fmt.Errorf("failed to check index %q: %w", index, err)
+	}
+	if exists {
+		return fmt.Errorf("index %q exists without the write alias %q", index, alias)
+	}
+
+	definition, err := d.rolloverIndexDefinition(alias)
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.CreateIndex(ctx, index, definition); err != nil {
+		return fmt.Errorf("failed to create index %q: %w", index, err)
+	}
+
+	sdk.Logger(ctx).Info().
+		Str("index", index).
+		Str("alias", alias).
+		Msg("rollover alias bootstrapped")
+
+	return nil
+}
+
+// rolloverIndexDefinition returns the definition of the initial index of the rollover alias.
+func (d *Destination) rolloverIndexDefinition(alias string) (api.IndexDefinition, error) {
+	settings := make(map[string]interface{})
+	if len(d.indexDefinition.Settings) > 0 {
+		if err := json.Unmarshal(d.indexDefinition.Settings, &settings); err != nil {
+			return api.IndexDefinition{}, fmt.Errorf("invalid index settings: %w", err)
+		}
+	}
+
+	settings["index.lifecycle.name"] = d.config.ILMPolicy
+	settings["index.lifecycle.rollover_alias"] = alias
+
+	encodedSettings, err := json.Marshal(settings)
+	if err != nil {
+		return api.IndexDefinition{}, fmt.Errorf("failed to prepare index settings: %w", err)
+	}
+
+	definition := d.indexDefinition
+	definition.WriteAlias = alias
+	definition.Settings = encodedSettings
+
+	return definition, nil
+}
@@ -8,18 +8,76 @@ import (
 )
 
 const (
-	ConfigAPIKey                 = "APIKey"
-	ConfigBulkSize               = "bulkSize"
-	ConfigCertificateFingerprint = "certificateFingerprint"
-	ConfigCloudID                = "cloudID"
-	ConfigHost                   = "host"
-	ConfigIndex                  = "index"
-	ConfigPassword               = "password"
-	ConfigRetries                = "retries"
-	ConfigServiceToken           = "serviceToken"
-	ConfigType                   = "type"
-	ConfigUsername               = "username"
-	ConfigVersion                = "version"
+	ConfigAPIKey                      = "APIKey"
+	ConfigAdaptiveBulkMaxDelay        = "adaptiveBulkMaxDelay"
+	ConfigAdaptiveBulkMinSize         = "adaptiveBulkMinSize"
+	ConfigAdaptiveBulkSize            = "adaptiveBulkSize"
+	ConfigAdaptiveBulkTargetLatency   = "adaptiveBulkTargetLatency"
+	ConfigBulkMaxBytes                = "bulkMaxBytes"
+	ConfigBulkSize                    = "bulkSize"
+	ConfigBulkTimeout                 = "bulkTimeout"
+	ConfigBulkWorkers                 = "bulkWorkers"
+	ConfigCertificateFingerprint      = "certificateFingerprint"
+	ConfigCloudID                     = "cloudID"
+	ConfigCompactOperations           = "compactOperations"
+	ConfigCompressRequestBody         = "compressRequestBody"
+	ConfigCompressionLevel            = "compressionLevel"
+	ConfigDataStream                  = "dataStream"
+	ConfigDataStreamDeletePolicy      = "dataStreamDeletePolicy"
+	ConfigDataStreamTimestampField    = "dataStreamTimestampField"
+	ConfigDataStreamTimestampMetadata = "dataStreamTimestampMetadata"
+	ConfigDataStreamUpdatePolicy      = "dataStreamUpdatePolicy"
+	ConfigDeadLetterIndex             = "deadLetterIndex"
+	ConfigDeleteByQueryFields         = "deleteByQueryFields"
+	ConfigDeletePolicy                = "deletePolicy"
+	ConfigDropNullFields              = "dropNullFields"
+	ConfigErrorPolicy                 = "errorPolicy"
+	ConfigExcludeFields               = "excludeFields"
+	ConfigFlattenFields               = "flattenFields"
+	ConfigHost                        = "host"
+	ConfigIdTemplate                  = "idTemplate"
+	ConfigIlmPolicy                   = "ilmPolicy"
+	ConfigIncludeFields               = "includeFields"
+	ConfigIndex                       = "index"
+	ConfigIndexMappings               = "indexMappings"
+	ConfigIndexSettings               = "indexSettings"
+	ConfigIndexTemplate               = "indexTemplate"
+	ConfigIndexTemplatePatterns       = "indexTemplatePatterns"
+	ConfigIndexTemplateType           = "indexTemplateType"
+	ConfigKeyFormat                   = "keyFormat"
+	ConfigKeySeparator                = "keySeparator"
+	ConfigKeylessID                   = "keylessID"
+	ConfigKeylessIDFields             = "keylessIDFields"
+	ConfigMetadataFields              = "metadataFields"
+	ConfigMetadataFieldsPrefix        = "metadataFieldsPrefix"
+	ConfigPassword                    = "password"
+	ConfigPipeline                    = "pipeline"
+	ConfigRefresh                     = "refresh"
+	ConfigRenameFields                = "renameFields"
+	ConfigRetries                     = "retries"
+	ConfigRetryMaxDelay               = "retryMaxDelay"
+	ConfigRetryMinDelay               = "retryMinDelay"
+	ConfigRetryOnConflict             = "retryOnConflict"
+	ConfigRollover                    = "rollover"
+	ConfigRouting                     = "routing"
+	ConfigSchemaMappings              = "schemaMappings"
+	ConfigSchemaStringType            = "schemaStringType"
+	ConfigScript                      = "script"
+	ConfigScriptID                    = "scriptID"
+	ConfigScriptParams                = "scriptParams"
+	ConfigScriptUpsert                = "scriptUpsert"
+	ConfigServiceToken                = "serviceToken"
+	ConfigSoftDeleteField             = "softDeleteField"
+	ConfigSoftDeleteTimeField         = "softDeleteTimeField"
+	ConfigType                        = "type"
+	ConfigUpdateDiff                  = "updateDiff"
+	ConfigUpdateDiffRemovedFields     = "updateDiffRemovedFields"
+	ConfigUsername                    = "username"
+	ConfigVersion                     = "version"
+	ConfigVersionTemplate             = "versionTemplate"
+	ConfigVersionType                 = "versionType"
+	ConfigWaitForActiveShards         = "waitForActiveShards"
+	ConfigWriteMode                   = "writeMode"
 )
 
 func (Config) Parameters() map[string]config.Parameter {
@@ -30,12 +88,62 @@ func (Config) Parameters() map[string]config.Parameter {
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigAdaptiveBulkMaxDelay: {
+			Default:     "5s",
+			Description: "The maximum pause between bulk requests in the `adaptiveBulkSize` mode.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigAdaptiveBulkMinSize: {
+			Default:     "10",
+			Description: "The minimum number of items per bulk request in the `adaptiveBulkSize` mode.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+				config.ValidationLessThan{V: 10001},
+			},
+		},
+		ConfigAdaptiveBulkSize: {
+			Default:     "",
+			Description: "Whether the number of items per bulk request adapts to the backpressure of the cluster between `adaptiveBulkMinSize` and `bulkSize`. The size is halved and the requests are paced when items are rejected with 429 or `es_rejected_execution_exception`, it shrinks when the requests are slower than `adaptiveBulkTargetLatency` and grows back otherwise.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigAdaptiveBulkTargetLatency: {
+			Default:     "2s",
+			Description: "The bulk request latency above which the `adaptiveBulkSize` mode shrinks the requests.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigBulkMaxBytes: {
+			Default:     "10485760",
+			Description: "The maximum size of a single bulk request body in bytes. Records exceeding the limit are sent in subsequent requests.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
 		ConfigBulkSize: {
 			Default:     "1000",
 			Description: "The number of items stored in bulk in the index. The minimum value is `1`, maximum value is `10 000`.",
 			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+				config.ValidationLessThan{V: 10001},
+			},
+		},
+		ConfigBulkTimeout: {
+			Default:     "",
+			Description: "The time the bulk requests wait for the active shards. If zero, the Elasticsearch default is used.",
+			Type:        config.ParameterTypeDuration,
 			Validations: []config.Validation{},
 		},
+		ConfigBulkWorkers: {
+			Default:     "1",
+			Description: "The number of bulk requests sent concurrently. The records are partitioned among the workers by their Document IDs, so the operations on the same Document are written in order.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
 		ConfigCertificateFingerprint: {
 			Default:     "",
 			Description: "SHA256 hex fingerprint given by Elasticsearch on first launch.",
@@ -48,6 +156,109 @@ func (Config) Parameters() map[string]config.Parameter {
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigCompactOperations: {
+			Default:     "",
+			Description: "Whether the earlier operations on the same Document in a batch are skipped when a later one replaces the whole Document. Only a hard delete or a write in the `index` mode supersedes the earlier operations, writes in the `update` mode merge into the Document and are kept. The superseded records are reported as written along with the superseding one and are handled by `errorPolicy` along with it when it's rejected. Scripted, diff, soft delete and versioned operations and data streams don't supersede other operations.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigCompressRequestBody: {
+			Default:     "",
+			Description: "Whether the request bodies are compressed with gzip.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigCompressionLevel: {
+			Default:     "",
+			Description: "The gzip compression level of the request bodies, from `1` (best speed) to `9` (best compression). If `0`, the default level is used.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+				config.ValidationLessThan{V: 10},
+			},
+		},
+		ConfigDataStream: {
+			Default:     "",
+			Description: "Whether the index is a data stream, supported by Elasticsearch 7.9 and later. Documents are only appended with the `create` operation, `writeMode` is ignored.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigDataStreamDeletePolicy: {
+			Default:     "fail",
+			Description: "The policy of handling deletes written to a data stream. One of: `skip` (logs the record and continues), `fail` (stops writing) or `deadLetterIndex` (writes the record to `deadLetterIndex`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"skip", "fail", "deadLetterIndex"}},
+			},
+		},
+		ConfigDataStreamTimestampField: {
+			Default:     "",
+			Description: "The payload field the `@timestamp` of the Documents written to a data stream is copied from. If empty, the `@timestamp` payload field is kept, or set from `dataStreamTimestampMetadata`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigDataStreamTimestampMetadata: {
+			Default:     "opencdc.readAt",
+			Description: "The metadata field the `@timestamp` of the Documents written to a data stream is set from when the payload has none. One of: `opencdc.readAt` or `opencdc.createdAt`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"opencdc.readAt", "opencdc.createdAt"}},
+			},
+		},
+		ConfigDataStreamUpdatePolicy: {
+			Default:     "append",
+			Description: "The policy of handling updates written to a data stream. One of: `append` (appends the record as a new Document), `skip` (logs the record and continues), `fail` (stops writing) or `deadLetterIndex` (writes the record to `deadLetterIndex`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"append", "skip", "fail", "deadLetterIndex"}},
+			},
+		},
+		ConfigDeadLetterIndex: {
+			Default:     "",
+			Description: "The name of the index the rejected records are written to by the `deadLetterIndex` error policy.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigDeleteByQueryFields: {
+			Default:     "",
+			Description: "The payload fields matched by the delete by query issued for deletes without a key. Their values are taken from the record's `before` payload and matched exactly, so string fields must be mapped as `keyword`, not as analyzed `text`. Only Documents visible to search are matched, so Documents written earlier in the same batch are matched only with `refresh` set to `true` or `wait_for`. If empty, deletes without a key fail.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigDeletePolicy: {
+			Default:     "hard",
+			Description: "The policy of handling deletes. One of: `hard` (deletes the Document), `soft` (marks the Document as deleted with `softDeleteField` and `softDeleteTimeField`) or `ignore` (leaves the Document untouched). Deletes written to a data stream are handled by `dataStreamDeletePolicy`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"hard", "soft", "ignore"}},
+			},
+		},
+		ConfigDropNullFields: {
+			Default:     "",
+			Description: "Whether null fields of the payload are dropped from the Documents.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigErrorPolicy: {
+			Default:     "fail",
+			Description: "The policy of handling records rejected by Elasticsearch, e.g. because of a mapping error. One of: `fail` (stops writing), `skip` (logs the rejected records and continues) or `deadLetterIndex` (writes the rejected records along with the errors to `deadLetterIndex` and continues). Records failing with a retryable status (429, 503 or 409) are not rejected, the write fails once `retries` are exhausted, so they are redelivered.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"fail", "skip", "deadLetterIndex"}},
+			},
+		},
+		ConfigExcludeFields: {
+			Default:     "",
+			Description: "The payload fields dropped from the Documents.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigFlattenFields: {
+			Default:     "",
+			Description: "Whether nested objects of the payload are flattened to fields with dotted names, e.g. `address.city`.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
 		ConfigHost: {
 			Default:     "",
 			Description: "The Elasticsearch host and port (e.g.: http://127.0.0.1:9200).",
@@ -56,36 +267,244 @@ func (Config) Parameters() map[string]config.Parameter {
 				config.ValidationRequired{},
 			},
 		},
+		ConfigIdTemplate: {
+			Default:     "",
+			Description: "The Document ID. It can contain a Go template that will be executed for each record to determine the ID. If empty, the ID is derived from the record's key according to `keyFormat`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIlmPolicy: {
+			Default:     "",
+			Description: "The name of the ILM policy managing the indices bootstrapped by the `rollover` mode. The bootstrapped indices are rolled over by the policy using the alias.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIncludeFields: {
+			Default:     "",
+			Description: "The payload fields kept in the Documents, all other fields are dropped. If empty, all fields are kept. Nested fields are referred to by their dotted names when `flattenFields` is enabled.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigIndex: {
 			Default:     "{{ index .Metadata \"opencdc.collection\" }}",
 			Description: "The name of the index to write the data to.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigIndexMappings: {
+			Default:     "",
+			Description: "The mappings of the indices created by the connector, either inline JSON or a path to a JSON file. Indices are created when missing unless `indexTemplate` is set.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIndexSettings: {
+			Default:     "",
+			Description: "The settings of the indices created by the connector, either inline JSON or a path to a JSON file. Indices are created when missing unless `indexTemplate` is set.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIndexTemplate: {
+			Default:     "",
+			Description: "The name of the template holding `indexMappings` and `indexSettings`. The template is created when missing, instead of creating the indices.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIndexTemplatePatterns: {
+			Default:     "",
+			Description: "The patterns of the index names the `index` template is applied to.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIndexTemplateType: {
+			Default:     "index",
+			Description: "The type of `indexTemplate`. One of: `index` (applied to the indices matching `indexTemplatePatterns`) or `component` (composed into index templates). With `version` 7 or 8 both are composable templates, which require Elasticsearch 7.8 or later. With `version` 5 or 6 `index` is a legacy template.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"index", "component"}},
+			},
+		},
+		ConfigKeyFormat: {
+			Default:     "json",
+			Description: "The format of the Document ID derived from a structured key. One of: `json` (the key encoded as JSON), `join` (the values of the key fields sorted by their names, joined with `keySeparator`) or `hash` (the hex encoded SHA-256 hash of the key).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"json", "join", "hash"}},
+			},
+		},
+		ConfigKeySeparator: {
+			Default:     "_",
+			Description: "The separator of the key field values used by the `join` key format.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigKeylessID: {
+			Default:     "none",
+			Description: "The strategy of deriving the Document ID of records without a key, so replayed records overwrite the same Document. One of: `none` (the ID is generated by Elasticsearch), `position` (the hash of the record's collection and position) or `payload` (the hash of the payload fields listed in `keylessIDFields`, or of the whole payload).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"none", "position", "payload"}},
+			},
+		},
+		ConfigKeylessIDFields: {
+			Default:     "",
+			Description: "The payload fields used to derive the Document ID by the `payload` keyless ID strategy.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigMetadataFields: {
+			Default:     "",
+			Description: "The record fields injected into the Documents. Any of: `collection`, `position`, `operation`, `createdAt` or `readAt`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigMetadataFieldsPrefix: {
+			Default:     "_conduit_",
+			Description: "The prefix of the names of the record fields injected into the Documents.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigPassword: {
 			Default:     "",
 			Description: "The password for HTTP Basic Authentication.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigPipeline: {
+			Default:     "",
+			Description: "The name of the ingest pipeline the Documents are processed by. It can contain a Go template that will be executed for each record to determine the pipeline. A static pipeline is checked to exist when the connector is opened. Requires the `index` or `create` write mode, or a data stream, as partial updates are not processed by pipelines.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigRefresh: {
+			Default:     "false",
+			Description: "The refresh policy of the bulk requests. One of: `false` (the changes become visible to search with the periodic refresh), `true` (refreshes the affected shards immediately) or `wait_for` (waits for the changes to become visible to search before the records are acknowledged).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"false", "true", "wait_for"}},
+			},
+		},
+		ConfigRenameFields: {
+			Default:     "",
+			Description: "The payload fields renamed in the Documents, as `from:to` pairs.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigRetries: {
 			Default:     "0",
-			Description: "The maximum number of retries of failed operations. The minimum value is `0` which disabled retry logic. The maximum value is `255.",
+			Description: "The maximum number of retries of failed operations. The minimum value is `0` which disabled retry logic. The maximum value is `255`. Only the items rejected with a retryable status (429, 503 or 409) are sent again, as well as whole bulk requests rejected with 429 or 503.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		ConfigRetryMaxDelay: {
+			Default:     "10s",
+			Description: "The maximum delay between retries of failed operations.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigRetryMinDelay: {
+			Default:     "100ms",
+			Description: "The initial delay before retrying failed operations. The delay grows exponentially with every retry.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigRetryOnConflict: {
+			Default:     "3",
+			Description: "The number of times an update is retried on a version conflict. Used by the `update` and `script` write modes.",
 			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigRollover: {
+			Default:     "",
+			Description: "Whether the index names are rollover aliases of indices managed by ILM. A missing alias is bootstrapped with the initial `<alias>-000001` index as its write index. Supported by Elasticsearch 6.6 and later.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigRouting: {
+			Default:     "",
+			Description: "The custom routing of the Document. It's a Go template executed for each record, e.g. `{{ .Key.tenant }}`. Deletes are routed using the record's `before` payload in place of the missing `after` payload, so they reach the same shard as the Document.",
+			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigSchemaMappings: {
+			Default:     "",
+			Description: "Whether the index mappings are derived from the Avro payload schemas of the records, fetched from the schema service. The index is created, or its mappings extended, before the first record of each schema version is written to it. Other schema types are skipped with a warning, their fields are mapped dynamically.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigSchemaStringType: {
+			Default:     "keyword",
+			Description: "The Elasticsearch type of the string fields of the payload schemas. One of: `keyword` or `text`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"keyword", "text"}},
+			},
+		},
+		ConfigScript: {
+			Default:     "",
+			Description: "The Painless script source used by the `script` write mode. The Document is available as `params.doc`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigScriptID: {
+			Default:     "",
+			Description: "The ID of the stored script used by the `script` write mode instead of `script`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigScriptParams: {
+			Default:     "",
+			Description: "The params of the script used by the `script` write mode. It's a Go template executed for each record that must result in a JSON object, e.g. `{\"count\": {{ index .Payload.After \"count\" }}}`. If empty, the Document is passed as `params.doc`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigScriptUpsert: {
+			Default:     "document",
+			Description: "The mode of inserting missing Documents by the `script` write mode. One of: `document` (inserts the record's payload), `scripted` (runs the script against an empty Document) or `none` (rejects the update, handled by `errorPolicy`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"document", "scripted", "none"}},
+			},
+		},
 		ConfigServiceToken: {
 			Default:     "",
 			Description: "Service token for authorization; if set, overrides username/password.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigSoftDeleteField: {
+			Default:     "deleted",
+			Description: "The field set to `true` in Documents deleted by the `soft` delete policy.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigSoftDeleteTimeField: {
+			Default:     "deleted_at",
+			Description: "The field set to the time of the deletion in Documents deleted by the `soft` delete policy, taken from the `opencdc.createdAt` or `opencdc.readAt` metadata. If empty, the time is not set.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigType: {
 			Default:     "",
 			Description: "The name of the index's type to write the data to.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigUpdateDiff: {
+			Default:     "",
+			Description: "Whether updates with a structured `before` and `after` payload send only the fields changed between them instead of the whole Document. Requires the `update` write mode.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigUpdateDiffRemovedFields: {
+			Default:     "remove",
+			Description: "The way of handling fields removed by an update in the `updateDiff` mode. One of: `remove` (removes the fields from the Document with a Painless script) or `null` (sets the fields to `null`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"remove", "null"}},
+			},
+		},
 		ConfigUsername: {
 			Default:     "",
 			Description: "The username for HTTP Basic Authentication.",
@@ -100,5 +519,33 @@ func (Config) Parameters() map[string]config.Parameter {
 				config.ValidationRequired{},
 			},
 		},
+		ConfigVersionTemplate: {
+			Default:     "",
+			Description: "The external version of the Document. It's a Go template executed for each record that must result in a non-negative integer, e.g. `{{ index .Metadata \"opencdc.readAt\" }}`. Documents are written only when the version is newer than the stored one, stale operations are skipped. Requires the `index` write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigVersionType: {
+			Default:     "external",
+			Description: "The type of the external version. One of: `external` (the version must be greater than the stored one) or `external_gte` (the version must be greater than or equal to the stored one).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"external", "external_gte"}},
+			},
+		},
+		ConfigWaitForActiveShards: {
+			Default:     "",
+			Description: "The number of shard copies that must be active before the bulk requests proceed, either a positive number or `all`. If empty, the Elasticsearch default is used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigWriteMode: {
+			Default:     "update",
+			Description: "The mode of writing Documents with an ID. One of: `update` (merges the Document, inserts it when missing), `index` (replaces the whole Document), `create` (skips Documents that already exist) or `script` (updates the Document with the script, inserts it when missing). In the `script` mode, the operations on the same Document are sent in separate bulk requests, so a retried script is neither reordered nor applied twice.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"update", "index", "create", "script"}},
+			},
+		},
 	}
 }
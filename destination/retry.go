@@ -0,0 +1,46 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"time"
+
+	"github.com/jpillora/backoff"
+)
+
+// newRetryBackoff returns the exponential backoff with jitter used between retries of failed bulk items.
+func (d *Destination) newRetryBackoff() *backoff.Backoff {
+	return &backoff.Backoff{
+		Factor: 2,
+		Jitter: true,
+		Min:    d.config.RetryMinDelay,
+		Max:    d.config.RetryMaxDelay,
+	}
+}
+
+// waitForRetry blocks for the given delay or until the context is cancelled.
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/conduitio/conduit-commons/schema"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/hamba/avro/v2"
+)
+
+// Elasticsearch field types of the string schema fields.
+const (
+	SchemaStringTypeKeyword = "keyword"
+	SchemaStringTypeText    = "text"
+)
+
+// errUnsupportedSchemaType is returned for the schemas that can't be translated to mappings.
+var errUnsupportedSchemaType = errors.New("unsupported schema type")
+
+// schemaField is the mapping of a single field derived from the schema.
+type schemaField map[string]interface{}
+
+// ensureSchemaMappings adds the mappings derived from the payload schemas of the records to their indices.
+// The index is created when missing. Each schema version is applied to each index only once.
+func (d *Destination) ensureSchemaMappings(ctx context.Context, records []opencdc.Record) error {
+	if !d.config.SchemaMappings {
+		return nil
+	}
+
+	for _, record := range records {
+		subject, err := record.Metadata.GetPayloadSchemaSubject()
+		if errors.Is(err, opencdc.ErrMetadataFieldNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		version, err := record.Metadata.GetPayloadSchemaVersion()
+		if err != nil {
+			return fmt.Errorf("invalid payload schema version: %w", err)
+		}
+
+		index, err := d.getIndexName(record)
+		if err != nil {
+			return err
+		}
+
+		key := index + "/" + subject + "/" + strconv.Itoa(version)
+		if _, ok := d.schemaMappedIndices[key]; ok {
+			continue
+		}
+
+		mappings, err := d.schemaMappings(ctx, subject, version)
+		if err != nil {
+			return err
+		}
+
+		// The fields of unsupported schemas are mapped dynamically
+		if mappings != nil {
+			if err := d.putMappings(ctx, index, mappings); err != nil {
+				return err
+			}
+		}
+
+		if d.schemaMappedIndices == nil {
+			d.schemaMappedIndices = make(map[string]struct{})
+		}
+		d.schemaMappedIndices[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// schemaMappings returns the mappings derived from the schema version. The mappings are cached per schema version.
+// It returns nil mappings when the schema type is not supported.
+func (d *Destination) schemaMappings(ctx context.Context, subject string, version int) (json.RawMessage, error) {
+	key := subject + "/" + strconv.Itoa(version)
+	if mappings, ok := d.schemaMappingsCache[key]; ok {
+		return mappings, nil
+	}
+
+	s, err := d.getSchema(ctx, subject, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema %q version %d: %w", subject, version, err)
+	}
+
+	mappings, err := schemaToMappings(s, d.config.SchemaStringType)
+	if errors.Is(err, errUnsupportedSchemaType) {
+		sdk.Logger(ctx).Warn().
+			Str("subject", subject).
+			Int("version", version).
+			Str("type", s.Type.String()).
+			Msg("schema type not supported, falling back to dynamic mapping")
+
+		mappings, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive mappings from schema %q version %d: %w", subject, version, err)
+	}
+
+	if d.schemaMappingsCache == nil {
+		d.schemaMappingsCache = make(map[string]json.RawMessage)
+	}
+	d.schemaMappingsCache[key] = mappings
+
+	return mappings, nil
+}
+
+// putMappings creates the index with the mappings or extends the mappings of the existing index.
+func (d *Destination) putMappings(ctx context.Context, index string, mappings json.RawMessage) error {
+	_, exists := d.ensuredIndices[index]
+	if !exists {
+		var err error
+		if exists, err = d.client.IndexExists(ctx, index); err != nil {
+			return fmt.Errorf("failed to check index %q: %w", index, err)
+		}
+	}
+
+	if !exists {
+		definition := d.indexDefinition
+		definition.Mappings = mappings
+
+		if err := d.client.CreateIndex(ctx, index, definition); err != nil {
+			return fmt.Errorf("failed to create index %q: %w", index, err)
+		}
+
+		sdk.Logger(ctx).Info().
+			Str("index", index).
+			Msg("index created")
+
+		return nil
+	}
+
+	if err := d.client.PutMapping(ctx, index, mappings); err != nil {
+		return fmt.Errorf("failed to put mappings of index %q: %w", index, err)
+	}
+
+	return nil
+}
+
+// schemaToMappings translates the schema to the Elasticsearch mappings.
+func schemaToMappings(s schema.Schema, stringType string) (json.RawMessage, error) {
+	if s.Type != schema.TypeAvro {
+		return nil, fmt.Errorf("%w %q", errUnsupportedSchemaType, s.Type)
+	}
+
+	avroSchema, err := avro.Parse(string(s.Bytes))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Avro schema: %w", err)
+	}
+
+	record, ok := avroSchema.(*avro.RecordSchema)
+	if !ok {
+		return nil, fmt.Errorf("expected an Avro record schema, got %q", avroSchema.Type())
+	}
+
+	return json.Marshal(avroRecordMapping(record, stringType))
+}
+
+// avroRecordMapping returns the mapping of the record fields.
+func avroRecordMapping(record *avro.RecordSchema, stringType string) schemaField {
+	properties := make(map[string]schemaField, len(record.Fields()))
+	for _, field := range record.Fields() {
+		if mapping := avroFieldMapping(field.Type(), stringType); mapping != nil {
+			properties[field.Name()] = mapping
+		}
+	}
+
+	return schemaField{"properties": properties}
+}
+
+// avroFieldMapping returns the mapping of the field of the Avro type.
+// It returns nil when the type can't be mapped, so the field is mapped dynamically.
+func avroFieldMapping(s avro.Schema, stringType string) schemaField {
+	switch s := s.(type) {
+	case *avro.RefSchema:
+		return avroFieldMapping(s.Schema(), stringType)
+
+	case *avro.RecordSchema:
+		mapping := avroRecordMapping(s, stringType)
+		mapping["type"] = "object"
+
+		return mapping
+
+	case *avro.ArraySchema:
+		// Arrays are implicit, apart from arrays of objects indexed independently of each other
+		mapping := avroFieldMapping(s.Items(), stringType)
+		if mapping != nil && mapping["type"] == "object" {
+			mapping["type"] = "nested"
+		}
+
+		return mapping
+
+	case *avro.MapSchema:
+		return schemaField{"type": "object"}
+
+	case *avro.UnionSchema:
+		// Only nullable types can be mapped
+		var types []avro.Schema
+		for _, t := range s.Types() {
+			if t.Type() != avro.Null {
+				types = append(types, t)
+			}
+		}
+		if len(types) != 1 {
+			return nil
+		}
+
+		return avroFieldMapping(types[0], stringType)
+
+	case *avro.EnumSchema:
+		return schemaField{"type": SchemaStringTypeKeyword}
+
+	case *avro.FixedSchema:
+		if s.Logical() != nil {
+			return avroLogicalMapping(s.Logical().Type())
+		}
+
+		return schemaField{"type": "binary"}
+
+	case *avro.PrimitiveSchema:
+		if s.Logical() != nil {
+			if mapping := avroLogicalMapping(s.Logical().Type()); mapping != nil {
+				return mapping
+			}
+		}
+
+		return avroPrimitiveMapping(s.Type(), stringType)
+
+	default:
+		return nil
+	}
+}
+
+// avroLogicalMapping returns the mapping of the Avro logical type.
+func avroLogicalMapping(t avro.LogicalType) schemaField {
+	switch t {
+	case avro.Date, avro.TimestampMillis, avro.TimestampMicros, avro.LocalTimestampMillis, avro.LocalTimestampMicros:
+		return schemaField{"type": "date"}
+	case avro.TimeMillis, avro.TimeMicros:
+		return schemaField{"type": "long"}
+	case avro.UUID:
+		return schemaField{"type": SchemaStringTypeKeyword}
+	case avro.Decimal:
+		return schemaField{"type": "double"}
+	default:
+		return nil
+	}
+}
+
+// avroPrimitiveMapping returns the mapping of the Avro primitive type.
+func avroPrimitiveMapping(t avro.Type, stringType string) schemaField {
+	switch t {
+	case avro.String:
+		return schemaField{"type": stringType}
+	case avro.Int:
+		return schemaField{"type": "integer"}
+	case avro.Long:
+		return schemaField{"type": "long"}
+	case avro.Float:
+		return schemaField{"type": "float"}
+	case avro.Double:
+		return schemaField{"type": "double"}
+	case avro.Boolean:
+		return schemaField{"type": "boolean"}
+	case avro.Bytes:
+		return schemaField{"type": "binary"}
+	default:
+		return nil
+	}
+}
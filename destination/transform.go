@@ -0,0 +1,221 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// Record fields injected into the Documents by the document transformation.
+const (
+	MetadataFieldCollection = "collection"
+	MetadataFieldPosition   = "position"
+	MetadataFieldOperation  = "operation"
+	MetadataFieldCreatedAt  = "createdAt"
+	MetadataFieldReadAt     = "readAt"
+)
+
+type TransformFn func(opencdc.Record) (opencdc.Record, error)
+
+// transformsDocuments reports whether any document transformation is configured.
+func (c Config) transformsDocuments() bool {
+	return len(c.IncludeFields) > 0 || len(c.ExcludeFields) > 0 || len(c.RenameFields) > 0 ||
+		c.FlattenFields || c.DropNullFields || len(c.MetadataFields) > 0
+}
+
+// renames parses the `from:to` pairs of the renamed fields.
+func (c Config) renames() (map[string]string, error) {
+	renames := make(map[string]string, len(c.RenameFields))
+	for _, pair := range c.RenameFields {
+		from, to, ok := strings.Cut(pair, ":")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("%q is not a valid rename, expected \"from:to\"", pair)
+		}
+
+		renames[from] = to
+	}
+
+	return renames, nil
+}
+
+// TransformFunction returns a function that shapes the Documents of each record before they are written.
+// The payloads are flattened first, then the fields are included, excluded, renamed and dropped when null,
+// and finally the record's metadata is injected into the `after` payload.
+// The `before` payload is transformed alike, without the metadata, so it matches the stored Document.
+func (c Config) TransformFunction() (TransformFn, error) {
+	renames, err := c.renames()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range c.MetadataFields {
+		switch field {
+		case MetadataFieldCollection, MetadataFieldPosition, MetadataFieldOperation,
+			MetadataFieldCreatedAt, MetadataFieldReadAt:
+		default:
+			return nil, fmt.Errorf("unknown metadata field %q", field)
+		}
+	}
+
+	include := fieldSet(c.IncludeFields)
+	exclude := fieldSet(c.ExcludeFields)
+
+	transform := func(data opencdc.Data) (opencdc.Data, error) {
+		if data == nil {
+			return nil, nil
+		}
+
+		payload, err := structuredPayload(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.FlattenFields {
+			flattened := make(opencdc.StructuredData, len(payload))
+			flattenFields(flattened, "", payload)
+			payload = flattened
+		}
+
+		doc := make(opencdc.StructuredData, len(payload)+len(c.MetadataFields))
+		for field, value := range payload {
+			if (len(include) > 0 && !include[field]) || exclude[field] {
+				continue
+			}
+
+			if c.DropNullFields {
+				if value = dropNullFields(value); value == nil {
+					continue
+				}
+			}
+
+			if name, ok := renames[field]; ok {
+				field = name
+			}
+
+			doc[field] = value
+		}
+
+		return doc, nil
+	}
+
+	return func(r opencdc.Record) (opencdc.Record, error) {
+		before, err := transform(r.Payload.Before)
+		if err != nil {
+			return opencdc.Record{}, fmt.Errorf("failed to transform before payload: %w", err)
+		}
+
+		after, err := transform(r.Payload.After)
+		if err != nil {
+			return opencdc.Record{}, fmt.Errorf("failed to transform after payload: %w", err)
+		}
+
+		if doc, ok := after.(opencdc.StructuredData); ok {
+			for _, field := range c.MetadataFields {
+				if value, ok := metadataField(r, field); ok {
+					doc[c.MetadataFieldsPrefix+field] = value
+				}
+			}
+		}
+
+		r.Payload.Before = before
+		r.Payload.After = after
+
+		return r, nil
+	}, nil
+}
+
+// fieldSet returns the set of the fields.
+func fieldSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+
+	return set
+}
+
+// flattenFields copies the fields into the Document, joining the names of nested fields with dots.
+// Arrays and empty objects are kept as they are.
+func flattenFields(doc opencdc.StructuredData, prefix string, fields map[string]interface{}) {
+	for field, value := range fields {
+		name := prefix + field
+
+		var nested map[string]interface{}
+		switch value := value.(type) {
+		case opencdc.StructuredData:
+			nested = value
+		case map[string]interface{}:
+			nested = value
+		}
+
+		if len(nested) == 0 {
+			doc[name] = value
+
+			continue
+		}
+
+		flattenFields(doc, name+".", nested)
+	}
+}
+
+// dropNullFields returns the value with the null fields of nested objects removed.
+func dropNullFields(value interface{}) interface{} {
+	var fields map[string]interface{}
+	switch value := value.(type) {
+	case opencdc.StructuredData:
+		fields = value
+	case map[string]interface{}:
+		fields = value
+	default:
+		return value
+	}
+
+	dropped := make(map[string]interface{}, len(fields))
+	for field, nested := range fields {
+		if nested = dropNullFields(nested); nested != nil {
+			dropped[field] = nested
+		}
+	}
+
+	return dropped
+}
+
+// metadataField returns the value of the record's field injected into the Document.
+func metadataField(r opencdc.Record, field string) (interface{}, bool) {
+	switch field {
+	case MetadataFieldCollection:
+		collection, err := r.Metadata.GetCollection()
+
+		return collection, err == nil
+	case MetadataFieldPosition:
+		return string(r.Position), r.Position != nil
+	case MetadataFieldOperation:
+		return r.Operation.String(), true
+	case MetadataFieldCreatedAt:
+		createdAt, err := r.Metadata.GetCreatedAt()
+
+		return createdAt.UTC().Format(time.RFC3339Nano), err == nil
+	case MetadataFieldReadAt:
+		readAt, err := r.Metadata.GetReadAt()
+
+		return readAt.UTC().Format(time.RFC3339Nano), err == nil
+	default:
+		return nil, false
+	}
+}
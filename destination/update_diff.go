@@ -0,0 +1,113 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// Ways of handling fields removed by an update in the diff mode.
+const (
+	// UpdateDiffRemovedFieldsRemove removes the fields from the Document.
+	UpdateDiffRemovedFieldsRemove = "remove"
+	// UpdateDiffRemovedFieldsNull sets the fields to null.
+	UpdateDiffRemovedFieldsNull = "null"
+)
+
+// diffsUpdates reports whether updates send only the changed fields.
+func (d *Destination) diffsUpdates() bool {
+	return d.config.UpdateDiff && d.config.GetWriteMode() == api.WriteModeUpdate
+}
+
+// writeDiffUpdateOperation adds update a Document with ID with the changed fields request into Bulk API request.
+// Records without both a structured before and after payload update the whole Document.
+func (d *Destination) writeDiffUpdateOperation(
+	key string,
+	data *bytes.Buffer,
+	item opencdc.Record,
+	index string,
+	options api.BulkOperationOptions,
+) error {
+	before, beforeOK := item.Payload.Before.(opencdc.StructuredData)
+	after, afterOK := item.Payload.After.(opencdc.StructuredData)
+	if !beforeOK || !afterOK {
+		return d.writeUpsertOperation(key, data, item, index, options)
+	}
+
+	document, err := json.Marshal(after)
+	if err != nil {
+		return fmt.Errorf("failed to prepare data with key=%s: %w", key, err)
+	}
+
+	diff := diffPayloads(before, after, d.config.UpdateDiffRemovedFields == UpdateDiffRemovedFieldsNull)
+	diff.Document = document
+
+	jsonEncoder := json.NewEncoder(data)
+
+	// Prepare data
+	metadata, payload, err := d.client.PrepareDiffUpdateOperation(key, index, diff, options)
+	if err != nil {
+		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
+	}
+
+	// Write metadata
+	if err := jsonEncoder.Encode(metadata); err != nil {
+		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
+	}
+
+	// Write payload
+	if err := jsonEncoder.Encode(payload); err != nil {
+		return fmt.Errorf("failed to prepare data with key=%s: %w", key, err)
+	}
+
+	return nil
+}
+
+// diffPayloads returns the top-level fields changed and removed between the before and after payloads.
+// If nullRemoved is set, the removed fields are reported as changed to nil instead.
+func diffPayloads(before, after opencdc.StructuredData, nullRemoved bool) api.DocumentDiff {
+	diff := api.DocumentDiff{
+		Changed: make(map[string]interface{}),
+	}
+
+	for field, value := range after {
+		if previous, ok := before[field]; !ok || !reflect.DeepEqual(previous, value) {
+			diff.Changed[field] = value
+		}
+	}
+
+	for field := range before {
+		if _, ok := after[field]; ok {
+			continue
+		}
+
+		if nullRemoved {
+			diff.Changed[field] = nil
+		} else {
+			diff.Removed = append(diff.Removed, field)
+		}
+	}
+
+	sort.Strings(diff.Removed)
+
+	return diff
+}
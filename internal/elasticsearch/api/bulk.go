@@ -0,0 +1,84 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Types of the external Document version.
+const (
+	// VersionTypeExternal applies the operation only when the version is greater than the stored one.
+	VersionTypeExternal = "external"
+	// VersionTypeExternalGTE applies the operation when the version is greater than or equal to the stored one.
+	VersionTypeExternalGTE = "external_gte"
+)
+
+// BulkOperationOptions holds the per-Document options of a Bulk API operation.
+type BulkOperationOptions struct {
+	// Version is the external version of the Document. Versioning is disabled when it's nil.
+	Version *int64
+	// VersionType is the type of the Version, one of VersionTypeExternal or VersionTypeExternalGTE.
+	VersionType string
+	// Routing is the custom routing value of the Document. The default routing is used when it's empty.
+	Routing string
+	// Pipeline is the name of the ingest pipeline the Document is processed by. Only index and create operations
+	// are processed by pipelines.
+	Pipeline string
+	// ScriptParams are the params of the script used by the script write mode. The Document is passed as the `doc`
+	// param when it's nil.
+	ScriptParams map[string]json.RawMessage
+}
+
+// Refresh policies of the Bulk API request.
+const (
+	// RefreshFalse doesn't refresh the affected shards.
+	RefreshFalse = "false"
+	// RefreshTrue refreshes the affected shards immediately, making the changes visible to search.
+	RefreshTrue = "true"
+	// RefreshWaitFor waits for the changes to be made visible to search by a refresh.
+	RefreshWaitFor = "wait_for"
+)
+
+// BulkRequestOptions holds the options of a Bulk API request.
+type BulkRequestOptions struct {
+	// Refresh is the refresh policy, one of RefreshFalse, RefreshTrue or RefreshWaitFor.
+	// The default policy is used when it's empty.
+	Refresh string
+	// WaitForActiveShards is the number of active shard copies required to proceed, or `all`.
+	// The default number is used when it's empty.
+	WaitForActiveShards string
+	// Timeout is the time to wait for the active shards. The default timeout is used when it's zero.
+	Timeout time.Duration
+	// FilterPath limits the fields of the response. The whole response is returned when it's empty.
+	FilterPath []string
+}
+
+// BulkRequestError is the failure of the whole Bulk API request, responded with a non-2xx status.
+type BulkRequestError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Err describes the failure reported by Elasticsearch.
+	Err error
+}
+
+func (e *BulkRequestError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BulkRequestError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,71 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DeleteByQueryRequest is the request deleting the Documents matching all the field values.
+type DeleteByQueryRequest struct {
+	Index   string
+	Match   map[string]interface{}
+	Routing string
+}
+
+// DeleteByQueryResponse is the JSON response from Elasticsearch delete by query request.
+type DeleteByQueryResponse struct {
+	Deleted int64 `json:"deleted"`
+	// Failures are the version conflicts and the shard failures that aborted the deletion.
+	Failures []json.RawMessage `json:"failures"`
+}
+
+// Err returns the error describing the failures of the delete by query, or nil when there are none.
+func (r DeleteByQueryResponse) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d failures after deleting %d documents, first failure: %s", len(r.Failures), r.Deleted, r.Failures[0])
+}
+
+// CreateDeleteByQueryBody creates the body of the delete by query request matching all the field values exactly.
+// The values are matched with term queries, so string values match only `keyword` fields, not analyzed `text` fields.
+func CreateDeleteByQueryBody(match map[string]interface{}) ([]byte, error) {
+	fields := make([]string, 0, len(match))
+	for field := range match {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	filters := make([]map[string]interface{}, len(fields))
+	for i, field := range fields {
+		filters[i] = map[string]interface{}{
+			"term": map[string]interface{}{
+				field: match[field],
+			},
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": filters,
+			},
+		},
+	})
+}
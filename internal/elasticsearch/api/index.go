@@ -0,0 +1,47 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import "encoding/json"
+
+// Types of templates holding the index definition.
+const (
+	// TemplateTypeIndex is an index template applied to the indices matching its patterns.
+	TemplateTypeIndex = "index"
+	// TemplateTypeComponent is a component template composed into index templates.
+	TemplateTypeComponent = "component"
+)
+
+// IndexDefinition holds the mappings and settings of an index.
+type IndexDefinition struct {
+	Mappings json.RawMessage
+	Settings json.RawMessage
+	// WriteAlias is the alias the index is created as the write index of. No alias is created when it's empty.
+	WriteAlias string
+}
+
+// Template describes an index or component template holding the index definition.
+type Template struct {
+	// Name is the name of the template.
+	Name string
+	// Type is the type of the template, one of TemplateTypeIndex or TemplateTypeComponent.
+	Type string
+	// IndexPatterns are the patterns of the index names the index template is applied to.
+	IndexPatterns []string
+	// DataStream reports whether the indices matching the index template are data streams.
+	DataStream bool
+	// Definition is the definition of the indices created from the template.
+	Definition IndexDefinition
+}
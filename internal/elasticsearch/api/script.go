@@ -0,0 +1,25 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// Modes of inserting missing Documents by script updates.
+const (
+	// ScriptUpsertDocument inserts the record's payload as the Document.
+	ScriptUpsertDocument = "document"
+	// ScriptUpsertScripted runs the script against an empty Document.
+	ScriptUpsertScripted = "scripted"
+	// ScriptUpsertNone fails the update of a missing Document.
+	ScriptUpsertNone = "none"
+)
@@ -0,0 +1,51 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// DiffUpdateScript is the Painless script applying the changed fields to the Document and removing the removed ones.
+// Changed fields replace the existing values as a whole, including nested objects.
+const DiffUpdateScript = "ctx._source.putAll(params.doc); for (String field : params.removed) { ctx._source.remove(field) }"
+
+// DocumentDiff holds the changes of the Document made by an update.
+type DocumentDiff struct {
+	// Changed holds the fields added or changed by the update.
+	Changed map[string]interface{}
+	// Removed lists the fields removed by the update.
+	Removed []string
+	// Document is the whole updated Document inserted when it's missing.
+	Document json.RawMessage
+}
+
+// RequiresScript reports whether the diff can't be applied as a partial Document, which is merged recursively.
+func (d DocumentDiff) RequiresScript() bool {
+	if len(d.Removed) > 0 {
+		return true
+	}
+
+	for _, value := range d.Changed {
+		switch value.(type) {
+		case map[string]interface{}, opencdc.StructuredData:
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// WriteMode defines how Documents with an ID are written to an index.
+type WriteMode = string
+
+const (
+	// WriteModeUpdate merges the Document with the existing one, inserting it when missing.
+	WriteModeUpdate WriteMode = "update"
+	// WriteModeIndex replaces the whole existing Document.
+	WriteModeIndex WriteMode = "index"
+	// WriteModeCreate writes the Document only when it does not exist yet.
+	WriteModeCreate WriteMode = "create"
+	// WriteModeScript updates the existing Document with a script, inserting the Document when missing.
+	WriteModeScript WriteMode = "script"
+)
@@ -16,6 +16,7 @@ package elasticsearch
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 
 	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
@@ -30,16 +31,73 @@ type Client interface {
 
 	// Bulk executes Elasticsearch Bulk API request.
 	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
-	Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error)
+	Bulk(ctx context.Context, reader io.Reader, options api.BulkRequestOptions) (io.ReadCloser, error)
 
 	// PrepareCreateOperation prepares insert operation definition for Bulk API query.
-	PrepareCreateOperation(item opencdc.Record, index string) (metadata interface{}, payload interface{}, err error)
+	PrepareCreateOperation(
+		item opencdc.Record,
+		index string,
+		options api.BulkOperationOptions,
+	) (metadata interface{}, payload interface{}, err error)
 
 	// PrepareUpsertOperation prepares upsert operation definition for Bulk API query.
-	PrepareUpsertOperation(key string, item opencdc.Record, index string) (metadata interface{}, payload interface{}, err error)
+	PrepareUpsertOperation(
+		key string,
+		item opencdc.Record,
+		index string,
+		options api.BulkOperationOptions,
+	) (metadata interface{}, payload interface{}, err error)
+
+	// PrepareDiffUpdateOperation prepares partial update operation applying the changes of the Document for Bulk API query.
+	PrepareDiffUpdateOperation(
+		key string,
+		index string,
+		diff api.DocumentDiff,
+		options api.BulkOperationOptions,
+	) (metadata interface{}, payload interface{}, err error)
 
 	// PrepareDeleteOperation prepares delete operation definition for Bulk API query.
-	PrepareDeleteOperation(key string, index string) (metadata interface{}, err error)
+	PrepareDeleteOperation(key string, index string, options api.BulkOperationOptions) (metadata interface{}, err error)
+
+	// PrepareSoftDeleteOperation prepares partial update operation marking the Document as deleted for Bulk API query.
+	PrepareSoftDeleteOperation(
+		key string,
+		index string,
+		doc opencdc.StructuredData,
+		options api.BulkOperationOptions,
+	) (metadata interface{}, payload interface{}, err error)
+
+	// DeleteByQuery deletes the Documents matching all the field values of the request.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-delete-by-query.html
+	DeleteByQuery(ctx context.Context, request *api.DeleteByQueryRequest) (deleted int64, err error)
+
+	// PipelineExists checks whether the ingest pipeline exists.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/get-pipeline-api.html
+	PipelineExists(ctx context.Context, name string) (bool, error)
+
+	// IndexExists checks whether the index exists.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-exists.html
+	IndexExists(ctx context.Context, index string) (bool, error)
+
+	// AliasExists checks whether the alias exists.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-alias-exists.html
+	AliasExists(ctx context.Context, alias string) (bool, error)
+
+	// CreateIndex creates the index with the given definition. An already existing index is not an error.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html
+	CreateIndex(ctx context.Context, index string, definition api.IndexDefinition) error
+
+	// PutMapping adds the fields of the mappings to the mappings of the index.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-put-mapping.html
+	PutMapping(ctx context.Context, index string, mappings json.RawMessage) error
+
+	// TemplateExists checks whether the index or component template exists.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/index-templates.html
+	TemplateExists(ctx context.Context, template api.Template) (bool, error)
+
+	// PutTemplate creates or updates the index or component template.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/index-templates.html
+	PutTemplate(ctx context.Context, template api.Template) error
 
 	// Search calls the elasticsearch search api and retuns SearchResponse read from an index.
 	Search(ctx context.Context, request *api.SearchRequest) (*api.SearchResponse, error)
@@ -17,23 +17,42 @@ package v5
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/5.6/docs-bulk.html
 type bulkRequestActionAndMetadata struct {
 	Index  *bulkRequestIndexAction  `json:"index,omitempty"`
+	Create *bulkRequestCreateAction `json:"create,omitempty"`
 	Update *bulkRequestUpdateAction `json:"update,omitempty"`
 	Delete *bulkRequestDeleteAction `json:"delete,omitempty"`
 }
 
 type bulkRequestIndexAction struct {
-	Index string `json:"_index"`
-	Type  string `json:"_type"`
+	ID          string `json:"_id,omitempty"`
+	Index       string `json:"_index"`
+	Type        string `json:"_type"`
+	Routing     string `json:"_routing,omitempty"`
+	Pipeline    string `json:"pipeline,omitempty"`
+	Version     *int64 `json:"_version,omitempty"`
+	VersionType string `json:"_version_type,omitempty"`
+}
+
+type bulkRequestCreateAction struct {
+	ID       string `json:"_id"`
+	Index    string `json:"_index"`
+	Type     string `json:"_type"`
+	Routing  string `json:"_routing,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
 }
 
 type bulkRequestUpdateAction struct {
-	ID    string `json:"_id"`
-	Index string `json:"_index"`
-	Type  string `json:"_type"`
+	ID              string `json:"_id"`
+	Index           string `json:"_index"`
+	Type            string `json:"_type"`
+	Routing         string `json:"_routing,omitempty"`
+	RetryOnConflict int    `json:"_retry_on_conflict"`
 }
 
 type bulkRequestDeleteAction struct {
-	ID    string `json:"_id"`
-	Index string `json:"_index"`
-	Type  string `json:"_type"`
+	ID          string `json:"_id"`
+	Index       string `json:"_index"`
+	Type        string `json:"_type"`
+	Routing     string `json:"_routing,omitempty"`
+	Version     *int64 `json:"_version,omitempty"`
+	VersionType string `json:"_version_type,omitempty"`
 }
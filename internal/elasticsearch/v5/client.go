@@ -15,12 +15,15 @@
 package v5
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/opencdc"
 
 	"github.com/elastic/go-elasticsearch/v5"
@@ -36,6 +39,7 @@ func NewClient(cfg interface{}) (*Client, error) {
 		Addresses: []string{configTyped.GetHost()},
 		Username:  configTyped.GetUsername(),
 		Password:  configTyped.GetPassword(),
+		Transport: transport(configTyped),
 	})
 	if err != nil {
 		return nil, err
@@ -47,6 +51,23 @@ func NewClient(cfg interface{}) (*Client, error) {
 	}, nil
 }
 
+// transport returns the HTTP transport of the client, compressing the request bodies when configured.
+func transport(cfg config) http.RoundTripper {
+	if !cfg.GetCompressRequestBody() {
+		return http.DefaultTransport
+	}
+
+	level := cfg.GetCompressionLevel()
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	return &gzipTransport{
+		next:  http.DefaultTransport,
+		level: level,
+	}
+}
+
 type Client struct {
 	es  *elasticsearch.Client
 	cfg config
@@ -69,8 +90,15 @@ func (c *Client) Ping(ctx context.Context) error {
 	return nil
 }
 
-func (c *Client) Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
-	result, err := c.es.Bulk(reader, c.es.Bulk.WithContext(ctx))
+func (c *Client) Bulk(ctx context.Context, reader io.Reader, options api.BulkRequestOptions) (io.ReadCloser, error) {
+	result, err := c.es.Bulk(
+		reader,
+		c.es.Bulk.WithContext(ctx),
+		c.es.Bulk.WithRefresh(options.Refresh),
+		c.es.Bulk.WithWaitForActiveShards(options.WaitForActiveShards),
+		c.es.Bulk.WithTimeout(options.Timeout),
+		c.es.Bulk.WithFilterPath(options.FilterPath...),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -86,77 +114,234 @@ func (c *Client) Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, err
 
 		var errorDetails ErrorResponse
 		if err := json.Unmarshal(bodyContents, &errorDetails); err != nil {
-			return nil, errors.New(result.Status())
+			return nil, &api.BulkRequestError{
+				StatusCode: result.StatusCode,
+				Err:        errors.New(result.Status()),
+			}
 		}
 
-		return nil, fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason)
+		return nil, &api.BulkRequestError{
+			StatusCode: result.StatusCode,
+			Err:        fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason),
+		}
 	}
 
 	return result.Body, nil
 }
 
-func (c *Client) PrepareCreateOperation(item opencdc.Record, index string) (interface{}, interface{}, error) {
+func (c *Client) PrepareCreateOperation(
+	item opencdc.Record,
+	index string,
+	options api.BulkOperationOptions,
+) (interface{}, interface{}, error) {
 	// Prepare metadata
 	metadata := bulkRequestActionAndMetadata{
 		Index: &bulkRequestIndexAction{
-			Index: index,
-			Type:  c.cfg.GetType(),
+			Index:    index,
+			Type:     c.cfg.GetType(),
+			Routing:  options.Routing,
+			Pipeline: options.Pipeline,
 		},
 	}
 
+	return metadata, preparePayload(&item), nil
+}
+
+func (c *Client) PrepareUpsertOperation(
+	key string,
+	item opencdc.Record,
+	index string,
+	options api.BulkOperationOptions,
+) (interface{}, interface{}, error) {
 	// Prepare payload
-	payload, err := preparePayload(&item)
-	if err != nil {
-		return nil, nil, err
+	payload := preparePayload(&item)
+
+	switch c.cfg.GetWriteMode() {
+	case api.WriteModeIndex:
+		metadata := bulkRequestActionAndMetadata{
+			Index: &bulkRequestIndexAction{
+				ID:          key,
+				Index:       index,
+				Type:        c.cfg.GetType(),
+				Routing:     options.Routing,
+				Pipeline:    options.Pipeline,
+				Version:     options.Version,
+				VersionType: options.VersionType,
+			},
+		}
+
+		return metadata, payload, nil
+
+	case api.WriteModeCreate:
+		metadata := bulkRequestActionAndMetadata{
+			Create: &bulkRequestCreateAction{
+				ID:       key,
+				Index:    index,
+				Type:     c.cfg.GetType(),
+				Routing:  options.Routing,
+				Pipeline: options.Pipeline,
+			},
+		}
+
+		return metadata, payload, nil
+
+	case api.WriteModeScript:
+		metadata := bulkRequestActionAndMetadata{
+			Update: &bulkRequestUpdateAction{
+				ID:              key,
+				Index:           index,
+				Type:            c.cfg.GetType(),
+				Routing:         options.Routing,
+				RetryOnConflict: c.cfg.GetRetryOnConflict(),
+			},
+		}
+
+		return metadata, c.scriptSource(payload, options), nil
+
+	default:
+		metadata := bulkRequestActionAndMetadata{
+			Update: &bulkRequestUpdateAction{
+				ID:              key,
+				Index:           index,
+				Type:            c.cfg.GetType(),
+				Routing:         options.Routing,
+				RetryOnConflict: c.cfg.GetRetryOnConflict(),
+			},
+		}
+
+		return metadata, bulkRequestUpdateSource{
+			Doc:         payload,
+			DocAsUpsert: true,
+		}, nil
 	}
+}
 
-	return metadata, bulkRequestCreateSource(payload), nil
+func (c *Client) PrepareDeleteOperation(key string, index string, options api.BulkOperationOptions) (interface{}, error) {
+	return bulkRequestActionAndMetadata{
+		Delete: &bulkRequestDeleteAction{
+			ID:          key,
+			Index:       index,
+			Type:        c.cfg.GetType(),
+			Routing:     options.Routing,
+			Version:     options.Version,
+			VersionType: options.VersionType,
+		},
+	}, nil
 }
 
-func (c *Client) PrepareUpsertOperation(key string, item opencdc.Record, index string) (interface{}, interface{}, error) {
-	// Prepare metadata
+func (c *Client) PrepareDiffUpdateOperation(
+	key string,
+	index string,
+	diff api.DocumentDiff,
+	options api.BulkOperationOptions,
+) (interface{}, interface{}, error) {
+	changed, err := json.Marshal(diff.Changed)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	metadata := bulkRequestActionAndMetadata{
 		Update: &bulkRequestUpdateAction{
-			ID:    key,
-			Index: index,
-			Type:  c.cfg.GetType(),
+			ID:              key,
+			Index:           index,
+			Type:            c.cfg.GetType(),
+			Routing:         options.Routing,
+			RetryOnConflict: c.cfg.GetRetryOnConflict(),
 		},
 	}
 
-	// Prepare payload
-	var err error
+	if !diff.RequiresScript() {
+		return metadata, bulkRequestDiffSource{
+			Doc:    changed,
+			Upsert: diff.Document,
+		}, nil
+	}
 
-	payload := bulkRequestUpdateSource{
-		Doc:         nil,
-		DocAsUpsert: true,
+	removedFields := diff.Removed
+	if removedFields == nil {
+		removedFields = []string{}
 	}
 
-	payload.Doc, err = preparePayload(&item)
+	removed, err := json.Marshal(removedFields)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return metadata, payload, nil
+	return metadata, bulkRequestScriptSource{
+		Script: bulkRequestScript{
+			Source: api.DiffUpdateScript,
+			Lang:   "painless",
+			Params: map[string]json.RawMessage{
+				"doc":     changed,
+				"removed": removed,
+			},
+		},
+		Upsert: diff.Document,
+	}, nil
 }
 
-func (c *Client) PrepareDeleteOperation(key string, index string) (interface{}, error) {
-	return bulkRequestActionAndMetadata{
-		Delete: &bulkRequestDeleteAction{
-			ID:    key,
-			Index: index,
-			Type:  c.cfg.GetType(),
+func (c *Client) PrepareSoftDeleteOperation(
+	key string,
+	index string,
+	doc opencdc.StructuredData,
+	options api.BulkOperationOptions,
+) (interface{}, interface{}, error) {
+	metadata := bulkRequestActionAndMetadata{
+		Update: &bulkRequestUpdateAction{
+			ID:              key,
+			Index:           index,
+			Type:            c.cfg.GetType(),
+			Routing:         options.Routing,
+			RetryOnConflict: c.cfg.GetRetryOnConflict(),
 		},
+	}
+
+	// The missing Document is not recreated as a tombstone
+	return metadata, bulkRequestUpdateSource{
+		Doc: doc,
 	}, nil
 }
 
-// preparePayload encodes Record's payload as JSON.
-func preparePayload(item *opencdc.Record) (json.RawMessage, error) {
+// scriptSource prepares the script update of the Document, inserting it when missing according to the script upsert mode.
+func (c *Client) scriptSource(payload interface{}, options api.BulkOperationOptions) bulkRequestScriptSource {
+	script := bulkRequestScript{
+		ID:     c.cfg.GetScriptID(),
+		Params: options.ScriptParams,
+	}
+	if options.ScriptParams == nil {
+		script.Params = map[string]interface{}{
+			"doc": payload,
+		}
+	}
+	if script.ID == "" {
+		script.Source = c.cfg.GetScript()
+		script.Lang = "painless"
+	}
+
+	source := bulkRequestScriptSource{
+		Script: script,
+	}
+
+	switch c.cfg.GetScriptUpsert() {
+	case api.ScriptUpsertNone:
+	case api.ScriptUpsertScripted:
+		source.Upsert = json.RawMessage(`{}`)
+		source.ScriptedUpsert = true
+	default:
+		source.Upsert = payload
+	}
+
+	return source
+}
+
+// preparePayload returns Record's payload to be encoded as JSON straight into the Bulk API request.
+func preparePayload(item *opencdc.Record) interface{} {
 	switch itemPayload := item.Payload.After.(type) {
 	case opencdc.StructuredData:
-		return json.Marshal(itemPayload)
+		return itemPayload
 
 	default:
 		// Nothing more can be done, we can trust the source to provide valid JSON
-		return itemPayload.Bytes(), nil
+		return bulkRequestCreateSource(itemPayload.Bytes())
 	}
 }
@@ -19,5 +19,12 @@ type config interface {
 	GetHost() string
 	GetUsername() string
 	GetPassword() string
+	GetCompressRequestBody() bool
+	GetCompressionLevel() int
 	GetType() string
+	GetWriteMode() string
+	GetRetryOnConflict() int
+	GetScript() string
+	GetScriptID() string
+	GetScriptUpsert() string
 }
@@ -17,18 +17,39 @@ var _ config = &configMock{}
 //
 //		// make and configure a mocked config
 //		mockedconfig := &configMock{
+//			GetCompressRequestBodyFunc: func() bool {
+//				panic("mock out the GetCompressRequestBody method")
+//			},
+//			GetCompressionLevelFunc: func() int {
+//				panic("mock out the GetCompressionLevel method")
+//			},
 //			GetHostFunc: func() string {
 //				panic("mock out the GetHost method")
 //			},
 //			GetPasswordFunc: func() string {
 //				panic("mock out the GetPassword method")
 //			},
+//			GetRetryOnConflictFunc: func() int {
+//				panic("mock out the GetRetryOnConflict method")
+//			},
+//			GetScriptFunc: func() string {
+//				panic("mock out the GetScript method")
+//			},
+//			GetScriptIDFunc: func() string {
+//				panic("mock out the GetScriptID method")
+//			},
+//			GetScriptUpsertFunc: func() string {
+//				panic("mock out the GetScriptUpsert method")
+//			},
 //			GetTypeFunc: func() string {
 //				panic("mock out the GetType method")
 //			},
 //			GetUsernameFunc: func() string {
 //				panic("mock out the GetUsername method")
 //			},
+//			GetWriteModeFunc: func() string {
+//				panic("mock out the GetWriteMode method")
+//			},
 //		}
 //
 //		// use mockedconfig in code that requires config
@@ -36,37 +57,140 @@ var _ config = &configMock{}
 //
 //	}
 type configMock struct {
+	// GetCompressRequestBodyFunc mocks the GetCompressRequestBody method.
+	GetCompressRequestBodyFunc func() bool
+
+	// GetCompressionLevelFunc mocks the GetCompressionLevel method.
+	GetCompressionLevelFunc func() int
+
 	// GetHostFunc mocks the GetHost method.
 	GetHostFunc func() string
 
 	// GetPasswordFunc mocks the GetPassword method.
 	GetPasswordFunc func() string
 
+	// GetRetryOnConflictFunc mocks the GetRetryOnConflict method.
+	GetRetryOnConflictFunc func() int
+
+	// GetScriptFunc mocks the GetScript method.
+	GetScriptFunc func() string
+
+	// GetScriptIDFunc mocks the GetScriptID method.
+	GetScriptIDFunc func() string
+
+	// GetScriptUpsertFunc mocks the GetScriptUpsert method.
+	GetScriptUpsertFunc func() string
+
 	// GetTypeFunc mocks the GetType method.
 	GetTypeFunc func() string
 
 	// GetUsernameFunc mocks the GetUsername method.
 	GetUsernameFunc func() string
 
+	// GetWriteModeFunc mocks the GetWriteMode method.
+	GetWriteModeFunc func() string
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetCompressRequestBody holds details about calls to the GetCompressRequestBody method.
+		GetCompressRequestBody []struct {
+		}
+		// GetCompressionLevel holds details about calls to the GetCompressionLevel method.
+		GetCompressionLevel []struct {
+		}
 		// GetHost holds details about calls to the GetHost method.
 		GetHost []struct {
 		}
 		// GetPassword holds details about calls to the GetPassword method.
 		GetPassword []struct {
 		}
+		// GetRetryOnConflict holds details about calls to the GetRetryOnConflict method.
+		GetRetryOnConflict []struct {
+		}
+		// GetScript holds details about calls to the GetScript method.
+		GetScript []struct {
+		}
+		// GetScriptID holds details about calls to the GetScriptID method.
+		GetScriptID []struct {
+		}
+		// GetScriptUpsert holds details about calls to the GetScriptUpsert method.
+		GetScriptUpsert []struct {
+		}
 		// GetType holds details about calls to the GetType method.
 		GetType []struct {
 		}
 		// GetUsername holds details about calls to the GetUsername method.
 		GetUsername []struct {
 		}
+		// GetWriteMode holds details about calls to the GetWriteMode method.
+		GetWriteMode []struct {
+		}
+	}
+	lockGetCompressRequestBody sync.RWMutex
+	lockGetCompressionLevel    sync.RWMutex
+	lockGetHost                sync.RWMutex
+	lockGetPassword            sync.RWMutex
+	lockGetRetryOnConflict     sync.RWMutex
+	lockGetScript              sync.RWMutex
+	lockGetScriptID            sync.RWMutex
+	lockGetScriptUpsert        sync.RWMutex
+	lockGetType                sync.RWMutex
+	lockGetUsername            sync.RWMutex
+	lockGetWriteMode           sync.RWMutex
+}
+
+// GetCompressRequestBody calls GetCompressRequestBodyFunc.
+func (mock *configMock) GetCompressRequestBody() bool {
+	if mock.GetCompressRequestBodyFunc == nil {
+		panic("configMock.GetCompressRequestBodyFunc: method is nil but config.GetCompressRequestBody was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCompressRequestBody.Lock()
+	mock.calls.GetCompressRequestBody = append(mock.calls.GetCompressRequestBody, callInfo)
+	mock.lockGetCompressRequestBody.Unlock()
+	return mock.GetCompressRequestBodyFunc()
+}
+
+// GetCompressRequestBodyCalls gets all the calls that were made to GetCompressRequestBody.
+// Check the length with:
+//
+//	len(mockedconfig.GetCompressRequestBodyCalls())
+func (mock *configMock) GetCompressRequestBodyCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCompressRequestBody.RLock()
+	calls = mock.calls.GetCompressRequestBody
+	mock.lockGetCompressRequestBody.RUnlock()
+	return calls
+}
+
+// GetCompressionLevel calls GetCompressionLevelFunc.
+func (mock *configMock) GetCompressionLevel() int {
+	if mock.GetCompressionLevelFunc == nil {
+		panic("configMock.GetCompressionLevelFunc: method is nil but config.GetCompressionLevel was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCompressionLevel.Lock()
+	mock.calls.GetCompressionLevel = append(mock.calls.GetCompressionLevel, callInfo)
+	mock.lockGetCompressionLevel.Unlock()
+	return mock.GetCompressionLevelFunc()
+}
+
+// GetCompressionLevelCalls gets all the calls that were made to GetCompressionLevel.
+// Check the length with:
+//
+//	len(mockedconfig.GetCompressionLevelCalls())
+func (mock *configMock) GetCompressionLevelCalls() []struct {
+} {
+	var calls []struct {
 	}
-	lockGetHost     sync.RWMutex
-	lockGetPassword sync.RWMutex
-	lockGetType     sync.RWMutex
-	lockGetUsername sync.RWMutex
+	mock.lockGetCompressionLevel.RLock()
+	calls = mock.calls.GetCompressionLevel
+	mock.lockGetCompressionLevel.RUnlock()
+	return calls
 }
 
 // GetHost calls GetHostFunc.
@@ -123,6 +247,114 @@ func (mock *configMock) GetPasswordCalls() []struct {
 	return calls
 }
 
+// GetRetryOnConflict calls GetRetryOnConflictFunc.
+func (mock *configMock) GetRetryOnConflict() int {
+	if mock.GetRetryOnConflictFunc == nil {
+		panic("configMock.GetRetryOnConflictFunc: method is nil but config.GetRetryOnConflict was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetRetryOnConflict.Lock()
+	mock.calls.GetRetryOnConflict = append(mock.calls.GetRetryOnConflict, callInfo)
+	mock.lockGetRetryOnConflict.Unlock()
+	return mock.GetRetryOnConflictFunc()
+}
+
+// GetRetryOnConflictCalls gets all the calls that were made to GetRetryOnConflict.
+// Check the length with:
+//
+//	len(mockedconfig.GetRetryOnConflictCalls())
+func (mock *configMock) GetRetryOnConflictCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRetryOnConflict.RLock()
+	calls = mock.calls.GetRetryOnConflict
+	mock.lockGetRetryOnConflict.RUnlock()
+	return calls
+}
+
+// GetScript calls GetScriptFunc.
+func (mock *configMock) GetScript() string {
+	if mock.GetScriptFunc == nil {
+		panic("configMock.GetScriptFunc: method is nil but config.GetScript was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetScript.Lock()
+	mock.calls.GetScript = append(mock.calls.GetScript, callInfo)
+	mock.lockGetScript.Unlock()
+	return mock.GetScriptFunc()
+}
+
+// GetScriptCalls gets all the calls that were made to GetScript.
+// Check the length with:
+//
+//	len(mockedconfig.GetScriptCalls())
+func (mock *configMock) GetScriptCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetScript.RLock()
+	calls = mock.calls.GetScript
+	mock.lockGetScript.RUnlock()
+	return calls
+}
+
+// GetScriptID calls GetScriptIDFunc.
+func (mock *configMock) GetScriptID() string {
+	if mock.GetScriptIDFunc == nil {
+		panic("configMock.GetScriptIDFunc: method is nil but config.GetScriptID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetScriptID.Lock()
+	mock.calls.GetScriptID = append(mock.calls.GetScriptID, callInfo)
+	mock.lockGetScriptID.Unlock()
+	return mock.GetScriptIDFunc()
+}
+
+// GetScriptIDCalls gets all the calls that were made to GetScriptID.
+// Check the length with:
+//
+//	len(mockedconfig.GetScriptIDCalls())
+func (mock *configMock) GetScriptIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetScriptID.RLock()
+	calls = mock.calls.GetScriptID
+	mock.lockGetScriptID.RUnlock()
+	return calls
+}
+
+// GetScriptUpsert calls GetScriptUpsertFunc.
+func (mock *configMock) GetScriptUpsert() string {
+	if mock.GetScriptUpsertFunc == nil {
+		panic("configMock.GetScriptUpsertFunc: method is nil but config.GetScriptUpsert was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetScriptUpsert.Lock()
+	mock.calls.GetScriptUpsert = append(mock.calls.GetScriptUpsert, callInfo)
+	mock.lockGetScriptUpsert.Unlock()
+	return mock.GetScriptUpsertFunc()
+}
+
+// GetScriptUpsertCalls gets all the calls that were made to GetScriptUpsert.
+// Check the length with:
+//
+//	len(mockedconfig.GetScriptUpsertCalls())
+func (mock *configMock) GetScriptUpsertCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetScriptUpsert.RLock()
+	calls = mock.calls.GetScriptUpsert
+	mock.lockGetScriptUpsert.RUnlock()
+	return calls
+}
+
 // GetType calls GetTypeFunc.
 func (mock *configMock) GetType() string {
 	if mock.GetTypeFunc == nil {
@@ -176,3 +408,30 @@ func (mock *configMock) GetUsernameCalls() []struct {
 	mock.lockGetUsername.RUnlock()
 	return calls
 }
+
+// GetWriteMode calls GetWriteModeFunc.
+func (mock *configMock) GetWriteMode() string {
+	if mock.GetWriteModeFunc == nil {
+		panic("configMock.GetWriteModeFunc: method is nil but config.GetWriteMode was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetWriteMode.Lock()
+	mock.calls.GetWriteMode = append(mock.calls.GetWriteMode, callInfo)
+	mock.lockGetWriteMode.Unlock()
+	return mock.GetWriteModeFunc()
+}
+
+// GetWriteModeCalls gets all the calls that were made to GetWriteMode.
+// Check the length with:
+//
+//	len(mockedconfig.GetWriteModeCalls())
+func (mock *configMock) GetWriteModeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetWriteMode.RLock()
+	calls = mock.calls.GetWriteMode
+	mock.lockGetWriteMode.RUnlock()
+	return calls
+}
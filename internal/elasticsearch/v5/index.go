@@ -0,0 +1,251 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v5
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+
+	"github.com/elastic/go-elasticsearch/v5/esapi"
+)
+
+var errComponentTemplates = errors.New("component templates are not supported by Elasticsearch v5")
+
+// IndexExists checks whether the index exists.
+func (c *Client) IndexExists(ctx context.Context, index string) (bool, error) {
+	req := esapi.IndicesExistsRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return false, fmt.Errorf("error checking index: %w", err)
+	}
+	defer res.Body.Close()
+
+	return existsResponse(res)
+}
+
+// AliasExists checks whether the alias exists.
+func (c *Client) AliasExists(ctx context.Context, alias string) (bool, error) {
+	req := esapi.IndicesExistsAliasRequest{
+		Name: []string{alias},
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return false, fmt.Errorf("error checking alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	return existsResponse(res)
+}
+
+// CreateIndex creates the index with the given definition. An already existing index is not an error.
+func (c *Client) CreateIndex(ctx context.Context, index string, definition api.IndexDefinition) error {
+	body, err := json.Marshal(c.indexBody(definition))
+	if err != nil {
+		return fmt.Errorf("failed to prepare index definition: %w", err)
+	}
+
+	req := esapi.IndicesCreateRequest{
+		Index: index,
+		Body:  bytes.NewReader(body),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("error creating index: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		errorDetails, err := errorResponse(res)
+		if err != nil {
+			return err
+		}
+
+		// The index might have been created in the meantime
+		if errorDetails.Error.Type == "resource_already_exists_exception" ||
+			errorDetails.Error.Type == "index_already_exists_exception" {
+			return nil
+		}
+
+		return fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason)
+	}
+
+	return nil
+}
+
+// PutMapping adds the fields of the mappings to the mappings of the index.
+func (c *Client) PutMapping(ctx context.Context, index string, mappings json.RawMessage) error {
+	req := esapi.IndicesPutMappingRequest{
+		Index:        []string{index},
+		DocumentType: c.cfg.GetType(),
+		Body:         bytes.NewReader(mappings),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("error putting mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// TemplateExists checks whether the index template exists. Component templates are not supported.
+func (c *Client) TemplateExists(ctx context.Context, template api.Template) (bool, error) {
+	if template.Type == api.TemplateTypeComponent {
+		return false, errComponentTemplates
+	}
+
+	req := esapi.IndicesExistsTemplateRequest{
+		Name: []string{template.Name},
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return false, fmt.Errorf("error checking template: %w", err)
+	}
+	defer res.Body.Close()
+
+	return existsResponse(res)
+}
+
+// PutTemplate creates or updates the index template. Component templates are not supported.
+func (c *Client) PutTemplate(ctx context.Context, template api.Template) error {
+	if template.Type == api.TemplateTypeComponent {
+		return errComponentTemplates
+	}
+
+	templateBody, err := c.templateBody(template)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(templateBody)
+	if err != nil {
+		return fmt.Errorf("failed to prepare template: %w", err)
+	}
+
+	req := esapi.IndicesPutTemplateRequest{
+		Name: template.Name,
+		Body: bytes.NewReader(body),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("error putting template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// indexBody returns the body of the create index request. The mappings are defined for the configured type.
+func (c *Client) indexBody(definition api.IndexDefinition) indexBody {
+	body := indexBody{
+		Settings: definition.Settings,
+		Aliases:  indexAliases(definition),
+	}
+
+	switch {
+	case len(definition.Mappings) == 0:
+		// Leave the mappings out
+
+	case c.cfg.GetType() != "":
+		body.Mappings = typedMappings{
+			c.cfg.GetType(): definition.Mappings,
+		}
+
+	default:
+		body.Mappings = definition.Mappings
+	}
+
+	return body
+}
+
+// templateBody returns the body of the put index template request.
+func (c *Client) templateBody(template api.Template) (templateBody, error) {
+	if len(template.IndexPatterns) != 1 {
+		return templateBody{}, errors.New("templates of Elasticsearch v5 support exactly one index pattern")
+	}
+
+	return templateBody{
+		Template:  template.IndexPatterns[0],
+		indexBody: c.indexBody(template.Definition),
+	}, nil
+}
+
+// indexAliases returns the aliases of the created index.
+func indexAliases(definition api.IndexDefinition) map[string]aliasBody {
+	if definition.WriteAlias == "" {
+		return nil
+	}
+
+	return map[string]aliasBody{
+		definition.WriteAlias: {
+			IsWriteIndex: true,
+		},
+	}
+}
+
+// existsResponse interprets the response of an existence check.
+func existsResponse(res *esapi.Response) (bool, error) {
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if res.IsError() {
+		return false, errors.New(res.Status())
+	}
+
+	return true, nil
+}
+
+// errorResponse decodes the details of the failed response.
+func errorResponse(res *esapi.Response) (ErrorResponse, error) {
+	var errorDetails ErrorResponse
+	if err := json.NewDecoder(res.Body).Decode(&errorDetails); err != nil {
+		return ErrorResponse{}, errors.New(res.Status())
+	}
+
+	return errorDetails, nil
+}
+
+// responseError returns the error of the failed response.
+func responseError(res *esapi.Response) error {
+	errorDetails, err := errorResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason)
+}
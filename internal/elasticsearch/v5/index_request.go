@@ -0,0 +1,37 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v5
+
+import "encoding/json"
+
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/5.6/indices-create-index.html
+type indexBody struct {
+	Settings json.RawMessage      `json:"settings,omitempty"`
+	Mappings interface{}          `json:"mappings,omitempty"`
+	Aliases  map[string]aliasBody `json:"aliases,omitempty"`
+}
+
+type aliasBody struct {
+	IsWriteIndex bool `json:"is_write_index,omitempty"`
+}
+
+// typedMappings holds the mappings of the index per type.
+type typedMappings map[string]json.RawMessage
+
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/5.6/indices-templates.html
+type templateBody struct {
+	Template string `json:"template"`
+	indexBody
+}
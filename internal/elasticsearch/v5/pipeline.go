@@ -0,0 +1,46 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v5
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v5/esapi"
+)
+
+// PipelineExists checks whether the ingest pipeline with the given name exists.
+func (c *Client) PipelineExists(ctx context.Context, name string) (bool, error) {
+	req := esapi.IngestGetPipelineRequest{
+		PipelineID: name,
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return false, fmt.Errorf("error getting ingest pipeline: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if res.IsError() {
+		return false, fmt.Errorf("error ingest pipeline response: %s", res.String())
+	}
+
+	return true, nil
+}
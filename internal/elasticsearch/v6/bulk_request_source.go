@@ -24,6 +24,24 @@ func (b bulkRequestCreateSource) MarshalJSON() ([]byte, error) {
 }
 
 type bulkRequestOptionalSource struct {
-	Doc         json.RawMessage `json:"doc"`
-	DocAsUpsert bool            `json:"doc_as_upsert"`
+	Doc         interface{} `json:"doc"`
+	DocAsUpsert bool        `json:"doc_as_upsert"`
+}
+
+type bulkRequestDiffSource struct {
+	Doc    json.RawMessage `json:"doc"`
+	Upsert json.RawMessage `json:"upsert"`
+}
+
+type bulkRequestScriptSource struct {
+	Script         bulkRequestScript `json:"script"`
+	Upsert         interface{}       `json:"upsert,omitempty"`
+	ScriptedUpsert bool              `json:"scripted_upsert,omitempty"`
+}
+
+type bulkRequestScript struct {
+	Source string      `json:"source,omitempty"`
+	ID     string      `json:"id,omitempty"`
+	Lang   string      `json:"lang,omitempty"`
+	Params interface{} `json:"params"`
 }
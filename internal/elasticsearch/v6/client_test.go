@@ -15,9 +15,14 @@
 package v6
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/elastic/go-elasticsearch/v6"
@@ -38,6 +43,41 @@ func TestNewClient(t *testing.T) {
 	})
 }
 
+func TestGzipTransport(t *testing.T) {
+	var received *http.Request
+	transport := &gzipTransport{
+		next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			received = req
+
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		level: gzip.BestSpeed,
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:9200/_bulk", strings.NewReader(`{"index":{}}`))
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	require.Equal(t, "gzip", received.Header.Get("Content-Encoding"))
+	require.Empty(t, req.Header.Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(received.Body)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, `{"index":{}}`, string(body))
+}
+
+// roundTripperFunc is an adapter allowing the use of a function as http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
 func TestClient_GetClient(t *testing.T) {
 	esClient := &elasticsearch.Client{}
 
@@ -49,7 +89,7 @@ func TestClient_GetClient(t *testing.T) {
 }
 
 func TestClient_PrepareCreateOperation(t *testing.T) {
-	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+	t.Run("Prepares payload failing to encode", func(t *testing.T) {
 		client := Client{
 			cfg: &configMock{
 				GetTypeFunc: func() string {
@@ -65,10 +105,12 @@ func TestClient_PrepareCreateOperation(t *testing.T) {
 			opencdc.StructuredData{
 				"foo": complex64(1 + 2i),
 			},
-		), indexName)
+		), indexName, api.BulkOperationOptions{})
 
-		require.Nil(t, metadata)
-		require.Nil(t, payload)
+		require.NoError(t, err)
+		require.NotNil(t, metadata)
+
+		_, err = json.Marshal(payload)
 		require.EqualError(t, err, "json: unsupported type: complex64")
 	})
 
@@ -88,7 +130,7 @@ func TestClient_PrepareCreateOperation(t *testing.T) {
 			opencdc.StructuredData{
 				"foo": "bar",
 			},
-		), indexName)
+		), indexName, api.BulkOperationOptions{})
 
 		require.NoError(t, err)
 		require.NotNil(t, metadata)
@@ -101,20 +143,53 @@ func TestClient_PrepareCreateOperation(t *testing.T) {
 			},
 		}
 
-		expectedPayload := bulkRequestCreateSource([]byte(`{"foo":"bar"}`))
+		expectedPayload := opencdc.StructuredData{
+			"foo": "bar",
+		}
 
 		require.Equal(t, expectedMetadata, metadata)
 		require.Equal(t, expectedPayload, payload)
 	})
+
+	t.Run("Successfully prepares routed create operation with a pipeline", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+			},
+		}
+
+		metadata, _, err := client.PrepareCreateOperation(upsertRecord(), indexName, api.BulkOperationOptions{
+			Routing:  "tenant-1",
+			Pipeline: "geoip",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Index: &bulkRequestIndexAction{
+				Index:    indexName,
+				Type:     indexType,
+				Routing:  "tenant-1",
+				Pipeline: "geoip",
+			},
+		}, metadata)
+	})
 }
 
 func TestClient_PrepareUpsertOperation(t *testing.T) {
-	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+	t.Run("Prepares payload failing to encode", func(t *testing.T) {
 		client := Client{
 			cfg: &configMock{
 				GetTypeFunc: func() string {
 					return indexType
 				},
+				GetWriteModeFunc: func() string {
+					return api.WriteModeUpdate
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
 			},
 		}
 
@@ -132,10 +207,13 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 				},
 			),
 			indexName,
+			api.BulkOperationOptions{},
 		)
 
-		require.Nil(t, metadata)
-		require.Nil(t, payload)
+		require.NoError(t, err)
+		require.NotNil(t, metadata)
+
+		_, err = json.Marshal(payload)
 		require.EqualError(t, err, "json: unsupported type: complex64")
 	})
 
@@ -145,6 +223,12 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 				GetTypeFunc: func() string {
 					return indexType
 				},
+				GetWriteModeFunc: func() string {
+					return api.WriteModeUpdate
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
 			},
 		}
 
@@ -162,6 +246,7 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 				},
 			),
 			indexName,
+			api.BulkOperationOptions{},
 		)
 
 		require.NoError(t, err)
@@ -178,13 +263,187 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 		}
 
 		expectedPayload := bulkRequestOptionalSource{
-			Doc:         json.RawMessage(`{"foo":"baz"}`),
+			Doc:         opencdc.StructuredData{"foo": "baz"},
 			DocAsUpsert: true,
 		}
 
 		require.Equal(t, expectedMetadata, metadata)
 		require.Equal(t, expectedPayload, payload)
 	})
+
+	t.Run("Successfully prepares index operation in index write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+				GetWriteModeFunc: func() string {
+					return api.WriteModeIndex
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Index: &bulkRequestIndexAction{
+				ID:    "key",
+				Index: indexName,
+				Type:  indexType,
+			},
+		}, metadata)
+		require.Equal(t, opencdc.StructuredData{"foo": "baz"}, payload)
+	})
+
+	t.Run("Successfully prepares create operation in create write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+				GetWriteModeFunc: func() string {
+					return api.WriteModeCreate
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Create: &bulkRequestCreateAction{
+				ID:    "key",
+				Index: indexName,
+				Type:  indexType,
+			},
+		}, metadata)
+		require.Equal(t, opencdc.StructuredData{"foo": "baz"}, payload)
+	})
+
+	t.Run("Successfully prepares scripted update operation in script write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+				GetWriteModeFunc: func() string {
+					return api.WriteModeScript
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
+				GetScriptFunc: func() string {
+					return "ctx._source.putAll(params.doc)"
+				},
+				GetScriptIDFunc: func() string {
+					return ""
+				},
+				GetScriptUpsertFunc: func() string {
+					return api.ScriptUpsertDocument
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Update: &bulkRequestUpdateAction{
+				ID:              "key",
+				Index:           indexName,
+				Type:            indexType,
+				RetryOnConflict: 3,
+			},
+		}, metadata)
+		require.Equal(t, bulkRequestScriptSource{
+			Script: bulkRequestScript{
+				Source: "ctx._source.putAll(params.doc)",
+				Lang:   "painless",
+				Params: map[string]interface{}{
+					"doc": opencdc.StructuredData{"foo": "baz"},
+				},
+			},
+			Upsert: opencdc.StructuredData{"foo": "baz"},
+		}, payload)
+	})
+	t.Run("Successfully prepares stored script update operation with params in script write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+				GetWriteModeFunc: func() string {
+					return api.WriteModeScript
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
+				GetScriptIDFunc: func() string {
+					return "increment-counter"
+				},
+				GetScriptUpsertFunc: func() string {
+					return api.ScriptUpsertScripted
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{
+			ScriptParams: map[string]json.RawMessage{
+				"count": json.RawMessage(`1`),
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Update: &bulkRequestUpdateAction{
+				ID:              "key",
+				Index:           indexName,
+				Type:            indexType,
+				RetryOnConflict: 3,
+			},
+		}, metadata)
+		require.Equal(t, bulkRequestScriptSource{
+			Script: bulkRequestScript{
+				ID: "increment-counter",
+				Params: map[string]json.RawMessage{
+					"count": json.RawMessage(`1`),
+				},
+			},
+			Upsert:         json.RawMessage(`{}`),
+			ScriptedUpsert: true,
+		}, payload)
+	})
+	t.Run("Successfully prepares versioned index operation in index write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+				GetWriteModeFunc: func() string {
+					return api.WriteModeIndex
+				},
+			},
+		}
+
+		version := int64(42)
+
+		metadata, _, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{
+			Version:     &version,
+			VersionType: api.VersionTypeExternal,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Index: &bulkRequestIndexAction{
+				ID:          "key",
+				Index:       indexName,
+				Type:        indexType,
+				Version:     &version,
+				VersionType: api.VersionTypeExternal,
+			},
+		}, metadata)
+	})
 }
 
 func TestClient_PrepareDeleteOperation(t *testing.T) {
@@ -200,6 +459,7 @@ func TestClient_PrepareDeleteOperation(t *testing.T) {
 		metadata, err := client.PrepareDeleteOperation(
 			"key",
 			indexName,
+			api.BulkOperationOptions{},
 		)
 
 		require.NoError(t, err)
@@ -215,4 +475,221 @@ func TestClient_PrepareDeleteOperation(t *testing.T) {
 
 		require.Equal(t, expectedMetadata, metadata)
 	})
+
+	t.Run("Successfully prepares versioned delete operation", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+			},
+		}
+
+		version := int64(42)
+
+		metadata, err := client.PrepareDeleteOperation("key", indexName, api.BulkOperationOptions{
+			Version:     &version,
+			VersionType: api.VersionTypeExternalGTE,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Delete: &bulkRequestDeleteAction{
+				ID:          "key",
+				Index:       indexName,
+				Type:        indexType,
+				Version:     &version,
+				VersionType: api.VersionTypeExternalGTE,
+			},
+		}, metadata)
+	})
+
+	t.Run("Successfully prepares routed delete operation", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetTypeFunc: func() string {
+					return indexType
+				},
+			},
+		}
+
+		metadata, err := client.PrepareDeleteOperation("key", indexName, api.BulkOperationOptions{
+			Routing: "tenant-1",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Delete: &bulkRequestDeleteAction{
+				ID:      "key",
+				Index:   indexName,
+				Type:    indexType,
+				Routing: "tenant-1",
+			},
+		}, metadata)
+	})
+}
+
+func TestClient_templateBody(t *testing.T) {
+	client := Client{
+		cfg: &configMock{
+			GetTypeFunc: func() string {
+				return indexType
+			},
+		},
+	}
+
+	body, err := client.templateBody(api.Template{
+		Name:          "users",
+		IndexPatterns: []string{"users-*", "customers-*"},
+		Definition: api.IndexDefinition{
+			Mappings: json.RawMessage(`{"properties":{"name":{"type":"keyword"}}}`),
+			Settings: json.RawMessage(`{"number_of_shards":1}`),
+		},
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"index_patterns": ["users-*", "customers-*"],
+		"settings": {"number_of_shards": 1},
+		"mappings": {"`+indexType+`": {"properties": {"name": {"type": "keyword"}}}}
+	}`, string(data))
+}
+
+func TestClient_indexBody(t *testing.T) {
+	client := Client{
+		cfg: &configMock{
+			GetTypeFunc: func() string {
+				return ""
+			},
+		},
+	}
+
+	data, err := json.Marshal(client.indexBody(api.IndexDefinition{
+		Settings:   json.RawMessage(`{"index.lifecycle.name":"logs"}`),
+		WriteAlias: "logs",
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"settings": {"index.lifecycle.name": "logs"},
+		"aliases": {"logs": {"is_write_index": true}}
+	}`, string(data))
+}
+
+func TestClient_PrepareSoftDeleteOperation(t *testing.T) {
+	client := Client{
+		cfg: &configMock{
+			GetTypeFunc: func() string {
+				return indexType
+			},
+			GetRetryOnConflictFunc: func() int {
+				return 3
+			},
+		},
+	}
+
+	metadata, payload, err := client.PrepareSoftDeleteOperation(
+		"key",
+		indexName,
+		opencdc.StructuredData{"deleted": true},
+		api.BulkOperationOptions{Routing: "tenant-1"},
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, bulkRequestActionAndMetadata{
+		Update: &bulkRequestUpdateAction{
+			ID:              "key",
+			Index:           indexName,
+			Type:            indexType,
+			Routing:         "tenant-1",
+			RetryOnConflict: 3,
+		},
+	}, metadata)
+	require.Equal(t, bulkRequestOptionalSource{
+		Doc: opencdc.StructuredData{"deleted": true},
+	}, payload)
+}
+
+func TestClient_PrepareDiffUpdateOperation(t *testing.T) {
+	client := Client{
+		cfg: &configMock{
+			GetTypeFunc: func() string {
+				return indexType
+			},
+			GetRetryOnConflictFunc: func() int {
+				return 3
+			},
+		},
+	}
+
+	expectedMetadata := bulkRequestActionAndMetadata{
+		Update: &bulkRequestUpdateAction{
+			ID:              "key",
+			Index:           indexName,
+			Type:            indexType,
+			Routing:         "tenant-1",
+			RetryOnConflict: 3,
+		},
+	}
+
+	t.Run("Partial Document", func(t *testing.T) {
+		metadata, payload, err := client.PrepareDiffUpdateOperation(
+			"key",
+			indexName,
+			api.DocumentDiff{
+				Changed:  map[string]interface{}{"name": "John"},
+				Document: json.RawMessage(`{"age":30,"name":"John"}`),
+			},
+			api.BulkOperationOptions{Routing: "tenant-1"},
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, expectedMetadata, metadata)
+		require.Equal(t, bulkRequestDiffSource{
+			Doc:    json.RawMessage(`{"name":"John"}`),
+			Upsert: json.RawMessage(`{"age":30,"name":"John"}`),
+		}, payload)
+	})
+
+	t.Run("Script", func(t *testing.T) {
+		metadata, payload, err := client.PrepareDiffUpdateOperation(
+			"key",
+			indexName,
+			api.DocumentDiff{
+				Changed:  map[string]interface{}{"address": map[string]interface{}{"city": "Paris"}},
+				Document: json.RawMessage(`{"address":{"city":"Paris"}}`),
+			},
+			api.BulkOperationOptions{Routing: "tenant-1"},
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, expectedMetadata, metadata)
+		require.Equal(t, bulkRequestScriptSource{
+			Script: bulkRequestScript{
+				Source: api.DiffUpdateScript,
+				Lang:   "painless",
+				Params: map[string]json.RawMessage{
+					"doc":     json.RawMessage(`{"address":{"city":"Paris"}}`),
+					"removed": json.RawMessage(`[]`),
+				},
+			},
+			Upsert: json.RawMessage(`{"address":{"city":"Paris"}}`),
+		}, payload)
+	})
+}
+
+// upsertRecord returns an update Record with a StructuredData payload.
+func upsertRecord() opencdc.Record {
+	return sdk.SourceUtil{}.NewRecordUpdate(
+		nil,
+		nil,
+		nil,
+		opencdc.StructuredData{
+			"foo": "bar",
+		},
+		opencdc.StructuredData{
+			"foo": "baz",
+		},
+	)
 }
@@ -19,7 +19,14 @@ type config interface {
 	GetHost() string
 	GetUsername() string
 	GetPassword() string
+	GetCompressRequestBody() bool
+	GetCompressionLevel() int
 	GetCloudID() string
 	GetAPIKey() string
 	GetType() string
+	GetWriteMode() string
+	GetRetryOnConflict() int
+	GetScript() string
+	GetScriptID() string
+	GetScriptUpsert() string
 }
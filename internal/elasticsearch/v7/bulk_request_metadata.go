@@ -16,22 +16,39 @@ package v7
 
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/7.17/docs-bulk.html
 type bulkRequestActionAndMetadata struct {
+	Index  *bulkRequestIndexAction  `json:"index,omitempty"`
 	Create *bulkRequestCreateAction `json:"create,omitempty"`
 	Update *bulkRequestUpdateAction `json:"update,omitempty"`
 	Delete *bulkRequestDeleteAction `json:"delete,omitempty"`
 }
 
+type bulkRequestIndexAction struct {
+	ID          string `json:"_id"`
+	Index       string `json:"_index"`
+	Routing     string `json:"routing,omitempty"`
+	Pipeline    string `json:"pipeline,omitempty"`
+	Version     *int64 `json:"version,omitempty"`
+	VersionType string `json:"version_type,omitempty"`
+}
+
 type bulkRequestCreateAction struct {
-	Index string `json:"_index"`
+	ID       string `json:"_id,omitempty"`
+	Index    string `json:"_index"`
+	Routing  string `json:"routing,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
 }
 
 type bulkRequestUpdateAction struct {
 	ID              string `json:"_id"`
 	Index           string `json:"_index"`
+	Routing         string `json:"routing,omitempty"`
 	RetryOnConflict int    `json:"retry_on_conflict"`
 }
 
 type bulkRequestDeleteAction struct {
-	ID    string `json:"_id"`
-	Index string `json:"_index"`
+	ID          string `json:"_id"`
+	Index       string `json:"_index"`
+	Routing     string `json:"routing,omitempty"`
+	Version     *int64 `json:"version,omitempty"`
+	VersionType string `json:"version_type,omitempty"`
 }
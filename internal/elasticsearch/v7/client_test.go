@@ -15,9 +15,17 @@
 package v7
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/elastic/go-elasticsearch/v7"
@@ -46,7 +54,7 @@ func TestClient_GetClient(t *testing.T) {
 }
 
 func TestClient_PrepareCreateOperation(t *testing.T) {
-	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+	t.Run("Prepares payload failing to encode", func(t *testing.T) {
 		client := Client{
 			cfg: &configMock{},
 		}
@@ -58,10 +66,12 @@ func TestClient_PrepareCreateOperation(t *testing.T) {
 			opencdc.StructuredData{
 				"foo": complex64(1 + 2i),
 			},
-		), indexName)
+		), indexName, api.BulkOperationOptions{})
 
-		require.Nil(t, metadata)
-		require.Nil(t, payload)
+		require.NoError(t, err)
+		require.NotNil(t, metadata)
+
+		_, err = json.Marshal(payload)
 		require.EqualError(t, err, "json: unsupported type: complex64")
 	})
 
@@ -77,7 +87,7 @@ func TestClient_PrepareCreateOperation(t *testing.T) {
 			opencdc.StructuredData{
 				"foo": "bar",
 			},
-		), indexName)
+		), indexName, api.BulkOperationOptions{})
 
 		require.NoError(t, err)
 		require.NotNil(t, metadata)
@@ -89,17 +99,46 @@ func TestClient_PrepareCreateOperation(t *testing.T) {
 			},
 		}
 
-		expectedPayload := bulkRequestCreateSource([]byte(`{"foo":"bar"}`))
+		expectedPayload := opencdc.StructuredData{
+			"foo": "bar",
+		}
 
 		require.Equal(t, expectedMetadata, metadata)
 		require.Equal(t, expectedPayload, payload)
 	})
+
+	t.Run("Successfully prepares routed create operation with a pipeline", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{},
+		}
+
+		metadata, _, err := client.PrepareCreateOperation(upsertRecord(), indexName, api.BulkOperationOptions{
+			Routing:  "tenant-1",
+			Pipeline: "geoip",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Create: &bulkRequestCreateAction{
+				Index:    indexName,
+				Routing:  "tenant-1",
+				Pipeline: "geoip",
+			},
+		}, metadata)
+	})
 }
 
 func TestClient_PrepareUpsertOperation(t *testing.T) {
-	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+	t.Run("Prepares payload failing to encode", func(t *testing.T) {
 		client := Client{
-			cfg: &configMock{},
+			cfg: &configMock{
+				GetWriteModeFunc: func() string {
+					return api.WriteModeUpdate
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
+			},
 		}
 
 		metadata, payload, err := client.PrepareUpsertOperation(
@@ -116,16 +155,26 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 				},
 			),
 			indexName,
+			api.BulkOperationOptions{},
 		)
 
-		require.Nil(t, metadata)
-		require.Nil(t, payload)
+		require.NoError(t, err)
+		require.NotNil(t, metadata)
+
+		_, err = json.Marshal(payload)
 		require.EqualError(t, err, "json: unsupported type: complex64")
 	})
 
 	t.Run("Successfully prepares upsert operation", func(t *testing.T) {
 		client := Client{
-			cfg: &configMock{},
+			cfg: &configMock{
+				GetWriteModeFunc: func() string {
+					return api.WriteModeUpdate
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
+			},
 		}
 
 		metadata, payload, err := client.PrepareUpsertOperation(
@@ -142,6 +191,7 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 				},
 			),
 			indexName,
+			api.BulkOperationOptions{},
 		)
 
 		require.NoError(t, err)
@@ -157,13 +207,167 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 		}
 
 		expectedPayload := bulkRequestOptionalSource{
-			Doc:         json.RawMessage(`{"foo":"baz"}`),
+			Doc:         opencdc.StructuredData{"foo": "baz"},
 			DocAsUpsert: true,
 		}
 
 		require.Equal(t, expectedMetadata, metadata)
 		require.Equal(t, expectedPayload, payload)
 	})
+
+	t.Run("Successfully prepares index operation in index write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetWriteModeFunc: func() string {
+					return api.WriteModeIndex
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Index: &bulkRequestIndexAction{
+				ID:    "key",
+				Index: indexName,
+			},
+		}, metadata)
+		require.Equal(t, opencdc.StructuredData{"foo": "baz"}, payload)
+	})
+
+	t.Run("Successfully prepares create operation in create write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetWriteModeFunc: func() string {
+					return api.WriteModeCreate
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Create: &bulkRequestCreateAction{
+				ID:    "key",
+				Index: indexName,
+			},
+		}, metadata)
+		require.Equal(t, opencdc.StructuredData{"foo": "baz"}, payload)
+	})
+
+	t.Run("Successfully prepares scripted update operation in script write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetWriteModeFunc: func() string {
+					return api.WriteModeScript
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
+				GetScriptFunc: func() string {
+					return "ctx._source.putAll(params.doc)"
+				},
+				GetScriptIDFunc: func() string {
+					return ""
+				},
+				GetScriptUpsertFunc: func() string {
+					return api.ScriptUpsertDocument
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Update: &bulkRequestUpdateAction{
+				ID:              "key",
+				Index:           indexName,
+				RetryOnConflict: 3,
+			},
+		}, metadata)
+		require.Equal(t, bulkRequestScriptSource{
+			Script: bulkRequestScript{
+				Source: "ctx._source.putAll(params.doc)",
+				Lang:   "painless",
+				Params: map[string]interface{}{
+					"doc": opencdc.StructuredData{"foo": "baz"},
+				},
+			},
+			Upsert: opencdc.StructuredData{"foo": "baz"},
+		}, payload)
+	})
+	t.Run("Successfully prepares stored script update operation with params in script write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetWriteModeFunc: func() string {
+					return api.WriteModeScript
+				},
+				GetRetryOnConflictFunc: func() int {
+					return 3
+				},
+				GetScriptIDFunc: func() string {
+					return "increment-counter"
+				},
+				GetScriptUpsertFunc: func() string {
+					return api.ScriptUpsertScripted
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{
+			ScriptParams: map[string]json.RawMessage{
+				"count": json.RawMessage(`1`),
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Update: &bulkRequestUpdateAction{
+				ID:              "key",
+				Index:           indexName,
+				RetryOnConflict: 3,
+			},
+		}, metadata)
+		require.Equal(t, bulkRequestScriptSource{
+			Script: bulkRequestScript{
+				ID: "increment-counter",
+				Params: map[string]json.RawMessage{
+					"count": json.RawMessage(`1`),
+				},
+			},
+			Upsert:         json.RawMessage(`{}`),
+			ScriptedUpsert: true,
+		}, payload)
+	})
+	t.Run("Successfully prepares versioned index operation in index write mode", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetWriteModeFunc: func() string {
+					return api.WriteModeIndex
+				},
+			},
+		}
+
+		version := int64(42)
+
+		metadata, _, err := client.PrepareUpsertOperation("key", upsertRecord(), indexName, api.BulkOperationOptions{
+			Version:     &version,
+			VersionType: api.VersionTypeExternal,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Index: &bulkRequestIndexAction{
+				ID:          "key",
+				Index:       indexName,
+				Version:     &version,
+				VersionType: api.VersionTypeExternal,
+			},
+		}, metadata)
+	})
 }
 
 func TestClient_PrepareDeleteOperation(t *testing.T) {
@@ -175,6 +379,7 @@ func TestClient_PrepareDeleteOperation(t *testing.T) {
 		metadata, err := client.PrepareDeleteOperation(
 			"key",
 			indexName,
+			api.BulkOperationOptions{},
 		)
 
 		require.NoError(t, err)
@@ -189,4 +394,321 @@ func TestClient_PrepareDeleteOperation(t *testing.T) {
 
 		require.Equal(t, expectedMetadata, metadata)
 	})
+
+	t.Run("Successfully prepares versioned delete operation", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{},
+		}
+
+		version := int64(42)
+
+		metadata, err := client.PrepareDeleteOperation("key", indexName, api.BulkOperationOptions{
+			Version:     &version,
+			VersionType: api.VersionTypeExternalGTE,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Delete: &bulkRequestDeleteAction{
+				ID:          "key",
+				Index:       indexName,
+				Version:     &version,
+				VersionType: api.VersionTypeExternalGTE,
+			},
+		}, metadata)
+	})
+
+	t.Run("Successfully prepares routed delete operation", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{},
+		}
+
+		metadata, err := client.PrepareDeleteOperation("key", indexName, api.BulkOperationOptions{
+			Routing: "tenant-1",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, bulkRequestActionAndMetadata{
+			Delete: &bulkRequestDeleteAction{
+				ID:      "key",
+				Index:   indexName,
+				Routing: "tenant-1",
+			},
+		}, metadata)
+	})
+}
+
+func TestTemplateBody(t *testing.T) {
+	definition := api.IndexDefinition{
+		Mappings: json.RawMessage(`{"properties":{"name":{"type":"keyword"}}}`),
+	}
+
+	t.Run("index template of a data stream", func(t *testing.T) {
+		data, err := json.Marshal(templateBody(api.Template{
+			Name:          "logs",
+			Type:          api.TemplateTypeIndex,
+			IndexPatterns: []string{"logs-*"},
+			DataStream:    true,
+			Definition:    definition,
+		}))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"index_patterns": ["logs-*"],
+			"data_stream": {},
+			"template": {"mappings": {"properties": {"name": {"type": "keyword"}}}}
+		}`, string(data))
+	})
+
+	t.Run("component template", func(t *testing.T) {
+		data, err := json.Marshal(templateBody(api.Template{
+			Name:       "logs-mappings",
+			Type:       api.TemplateTypeComponent,
+			Definition: definition,
+		}))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"template": {"mappings": {"properties": {"name": {"type": "keyword"}}}}
+		}`, string(data))
+	})
+}
+
+func TestClient_indexBody(t *testing.T) {
+	client := Client{
+		cfg: &configMock{},
+	}
+
+	data, err := json.Marshal(client.indexBody(api.IndexDefinition{
+		Settings:   json.RawMessage(`{"index.lifecycle.name":"logs"}`),
+		WriteAlias: "logs",
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"settings": {"index.lifecycle.name": "logs"},
+		"aliases": {"logs": {"is_write_index": true}}
+	}`, string(data))
+}
+
+func TestClient_PrepareSoftDeleteOperation(t *testing.T) {
+	client := Client{
+		cfg: &configMock{
+			GetRetryOnConflictFunc: func() int {
+				return 3
+			},
+		},
+	}
+
+	metadata, payload, err := client.PrepareSoftDeleteOperation(
+		"key",
+		indexName,
+		opencdc.StructuredData{"deleted": true},
+		api.BulkOperationOptions{Routing: "tenant-1"},
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, bulkRequestActionAndMetadata{
+		Update: &bulkRequestUpdateAction{
+			ID:              "key",
+			Index:           indexName,
+			Routing:         "tenant-1",
+			RetryOnConflict: 3,
+		},
+	}, metadata)
+	require.Equal(t, bulkRequestOptionalSource{
+		Doc: opencdc.StructuredData{"deleted": true},
+	}, payload)
+}
+
+func TestClient_PrepareDiffUpdateOperation(t *testing.T) {
+	client := Client{
+		cfg: &configMock{
+			GetRetryOnConflictFunc: func() int {
+				return 3
+			},
+		},
+	}
+
+	expectedMetadata := bulkRequestActionAndMetadata{
+		Update: &bulkRequestUpdateAction{
+			ID:              "key",
+			Index:           indexName,
+			Routing:         "tenant-1",
+			RetryOnConflict: 3,
+		},
+	}
+
+	t.Run("Partial Document", func(t *testing.T) {
+		metadata, payload, err := client.PrepareDiffUpdateOperation(
+			"key",
+			indexName,
+			api.DocumentDiff{
+				Changed:  map[string]interface{}{"name": "John"},
+				Document: json.RawMessage(`{"age":30,"name":"John"}`),
+			},
+			api.BulkOperationOptions{Routing: "tenant-1"},
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, expectedMetadata, metadata)
+		require.Equal(t, bulkRequestDiffSource{
+			Doc:    json.RawMessage(`{"name":"John"}`),
+			Upsert: json.RawMessage(`{"age":30,"name":"John"}`),
+		}, payload)
+	})
+
+	t.Run("Script", func(t *testing.T) {
+		metadata, payload, err := client.PrepareDiffUpdateOperation(
+			"key",
+			indexName,
+			api.DocumentDiff{
+				Changed:  map[string]interface{}{"address": map[string]interface{}{"city": "Paris"}},
+				Document: json.RawMessage(`{"address":{"city":"Paris"}}`),
+			},
+			api.BulkOperationOptions{Routing: "tenant-1"},
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, expectedMetadata, metadata)
+		require.Equal(t, bulkRequestScriptSource{
+			Script: bulkRequestScript{
+				Source: api.DiffUpdateScript,
+				Lang:   "painless",
+				Params: map[string]json.RawMessage{
+					"doc":     json.RawMessage(`{"address":{"city":"Paris"}}`),
+					"removed": json.RawMessage(`[]`),
+				},
+			},
+			Upsert: json.RawMessage(`{"address":{"city":"Paris"}}`),
+		}, payload)
+	})
+}
+
+// upsertRecord returns an update Record with a StructuredData payload.
+func upsertRecord() opencdc.Record {
+	return sdk.SourceUtil{}.NewRecordUpdate(
+		nil,
+		nil,
+		nil,
+		opencdc.StructuredData{
+			"foo": "bar",
+		},
+		opencdc.StructuredData{
+			"foo": "baz",
+		},
+	)
+}
+
+func TestClient_DeleteByQuery(t *testing.T) {
+	newClient := func(t *testing.T, status int, body string) *Client {
+		esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				header := http.Header{"X-Elastic-Product": []string{"Elasticsearch"}}
+
+				// The product check of the client
+				if req.URL.Path == "/" {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     header,
+						Body:       io.NopCloser(strings.NewReader(`{"version":{"number":"7.17.0"},"tagline":"You Know, for Search"}`)),
+					}, nil
+				}
+
+				require.Equal(t, "/someIndexName/_delete_by_query", req.URL.Path)
+
+				return &http.Response{
+					StatusCode: status,
+					Header:     header,
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			}),
+		})
+		require.NoError(t, err)
+
+		return &Client{es: esClient}
+	}
+
+	request := &api.DeleteByQueryRequest{
+		Index: indexName,
+		Match: map[string]interface{}{"email": "john@example.com"},
+	}
+
+	t.Run("Returns the number of deleted documents", func(t *testing.T) {
+		client := newClient(t, http.StatusOK, `{"deleted":2,"failures":[]}`)
+
+		deleted, err := client.DeleteByQuery(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), deleted)
+	})
+
+	t.Run("Fails on version conflicts", func(t *testing.T) {
+		client := newClient(t, http.StatusConflict, `{"deleted":1,"failures":[{"id":"2","status":409}]}`)
+
+		deleted, err := client.DeleteByQuery(context.Background(), request)
+		require.EqualError(t, err, `1 failures after deleting 1 documents, first failure: {"id":"2","status":409}`)
+		require.Equal(t, int64(1), deleted)
+	})
+
+	t.Run("Fails on shard failures", func(t *testing.T) {
+		client := newClient(t, http.StatusOK, `{"deleted":0,"failures":[{"shard":0,"reason":{"type":"search_exception"}}]}`)
+
+		_, err := client.DeleteByQuery(context.Background(), request)
+		require.EqualError(t, err, `1 failures after deleting 0 documents, first failure: {"shard":0,"reason":{"type":"search_exception"}}`)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_Bulk_CompressionLevel(t *testing.T) {
+	body := strings.Repeat(`{"index":{"_index":"someIndexName"}}`+"\n"+`{"name":"john","city":"Warsaw"}`+"\n", 100)
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		// The product check of the client
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.0"},"tagline":"You Know, for Search"}`))
+
+			return
+		}
+
+		require.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+
+		var err error
+		received, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		_, _ = w.Write([]byte(`{"errors":false}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&configMock{
+		GetHostFunc:                   func() string { return server.URL },
+		GetUsernameFunc:               func() string { return "" },
+		GetPasswordFunc:               func() string { return "" },
+		GetCloudIDFunc:                func() string { return "" },
+		GetAPIKeyFunc:                 func() string { return "" },
+		GetServiceTokenFunc:           func() string { return "" },
+		GetCertificateFingerprintFunc: func() string { return "" },
+		GetCompressRequestBodyFunc:    func() bool { return true },
+		GetCompressionLevelFunc:       func() int { return gzip.BestSpeed },
+	})
+	require.NoError(t, err)
+
+	response, err := client.Bulk(context.Background(), strings.NewReader(body), api.BulkRequestOptions{})
+	require.NoError(t, err)
+	require.NoError(t, response.Close())
+
+	var expected bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&expected, gzip.BestSpeed)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, expected.Bytes(), received)
 }
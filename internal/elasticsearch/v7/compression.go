@@ -0,0 +1,103 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v7
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// gzipTransport compresses the request bodies with gzip, at the configured level, which the Elasticsearch v7 client doesn't support.
+type gzipTransport struct {
+	next  http.RoundTripper
+	level int
+}
+
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.next.RoundTrip(req)
+	}
+
+	var buf bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buf, t.level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip writer: %w", err)
+	}
+
+	_, err = io.Copy(writer, req.Body)
+	if closeErr := req.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	compressed := buf.Bytes()
+
+	// The RoundTripper must not modify the original request
+	gzipReq := req.Clone(req.Context())
+	gzipReq.Body = io.NopCloser(bytes.NewReader(compressed))
+	gzipReq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	gzipReq.ContentLength = int64(len(compressed))
+	gzipReq.Header.Set("Content-Encoding", "gzip")
+
+	return t.next.RoundTrip(gzipReq)
+}
+
+// fingerprintTransport returns the HTTP transport verifying the certificate of the server by its SHA256 fingerprint,
+// like the Elasticsearch v7 client does for its default transport.
+func fingerprintTransport(certificateFingerprint string) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if certificateFingerprint == "" {
+		return transport
+	}
+
+	transport.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		fingerprint, _ := hex.DecodeString(certificateFingerprint)
+
+		dialer := &tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}} //nolint:gosec // verified by the fingerprint
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cert := range conn.(*tls.Conn).ConnectionState().PeerCertificates {
+			digest := sha256.Sum256(cert.Raw)
+			if bytes.Equal(digest[:], fingerprint) {
+				return conn, nil
+			}
+		}
+
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("fingerprint mismatch, provided: %s", certificateFingerprint)
+	}
+
+	return transport
+}
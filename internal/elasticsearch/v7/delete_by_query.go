@@ -0,0 +1,66 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v7
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+// DeleteByQuery deletes the Documents matching all the field values of the request.
+// Only the Documents visible to search are matched. The deletion fails on any version conflict or shard failure.
+func (c *Client) DeleteByQuery(ctx context.Context, request *api.DeleteByQueryRequest) (int64, error) {
+	body, err := api.CreateDeleteByQueryBody(request.Match)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare query: %w", err)
+	}
+
+	req := esapi.DeleteByQueryRequest{
+		Index: []string{request.Index},
+		Body:  bytes.NewReader(body),
+	}
+	if request.Routing != "" {
+		req.Routing = []string{request.Routing}
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting by query: %w", err)
+	}
+	defer res.Body.Close()
+
+	// Version conflicts abort the deletion with the failures reported in the response
+	if res.IsError() && res.StatusCode != http.StatusConflict {
+		return 0, responseError(res)
+	}
+
+	var response api.DeleteByQueryResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("error parsing the delete by query response body: %w", err)
+	}
+
+	if err := response.Err(); err != nil {
+		return response.Deleted, err
+	}
+
+	return response.Deleted, nil
+}
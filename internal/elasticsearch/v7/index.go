@@ -0,0 +1,245 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v7
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/conduitio-labs/conduit-connector-elasticsearch/internal/elasticsearch/api"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+// IndexExists checks whether the index exists.
+func (c *Client) IndexExists(ctx context.Context, index string) (bool, error) {
+	req := esapi.IndicesExistsRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return false, fmt.Errorf("error checking index: %w", err)
+	}
+	defer res.Body.Close()
+
+	return existsResponse(res)
+}
+
+// AliasExists checks whether the alias exists.
+func (c *Client) AliasExists(ctx context.Context, alias string) (bool, error) {
+	req := esapi.IndicesExistsAliasRequest{
+		Name: []string{alias},
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return false, fmt.Errorf("error checking alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	return existsResponse(res)
+}
+
+// CreateIndex creates the index with the given definition. An already existing index is not an error.
+func (c *Client) CreateIndex(ctx context.Context, index string, definition api.IndexDefinition) error {
+	body, err := json.Marshal(c.indexBody(definition))
+	if err != nil {
+		return fmt.Errorf("failed to prepare index definition: %w", err)
+	}
+
+	req := esapi.IndicesCreateRequest{
+		Index: index,
+		Body:  bytes.NewReader(body),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("error creating index: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		errorDetails, err := errorResponse(res)
+		if err != nil {
+			return err
+		}
+
+		// The index might have been created in the meantime
+		if errorDetails.Error.Type == "resource_already_exists_exception" ||
+			errorDetails.Error.Type == "index_already_exists_exception" {
+			return nil
+		}
+
+		return fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason)
+	}
+
+	return nil
+}
+
+// PutMapping adds the fields of the mappings to the mappings of the index.
+func (c *Client) PutMapping(ctx context.Context, index string, mappings json.RawMessage) error {
+	req := esapi.IndicesPutMappingRequest{
+		Index: []string{index},
+		Body:  bytes.NewReader(mappings),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("error putting mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// TemplateExists checks whether the index or component template exists.
+func (c *Client) TemplateExists(ctx context.Context, template api.Template) (bool, error) {
+	var req esapi.Request = esapi.IndicesExistsIndexTemplateRequest{
+		Name: template.Name,
+	}
+	if template.Type == api.TemplateTypeComponent {
+		req = esapi.ClusterExistsComponentTemplateRequest{
+			Name: template.Name,
+		}
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return false, fmt.Errorf("error checking template: %w", err)
+	}
+	defer res.Body.Close()
+
+	return existsResponse(res)
+}
+
+// PutTemplate creates or updates the index or component template.
+// Both are composable templates, which require Elasticsearch 7.8 or later.
+func (c *Client) PutTemplate(ctx context.Context, template api.Template) error {
+	body, err := json.Marshal(templateBody(template))
+	if err != nil {
+		return fmt.Errorf("failed to prepare template: %w", err)
+	}
+
+	var req esapi.Request = esapi.IndicesPutIndexTemplateRequest{
+		Name: template.Name,
+		Body: bytes.NewReader(body),
+	}
+	if template.Type == api.TemplateTypeComponent {
+		req = esapi.ClusterPutComponentTemplateRequest{
+			Name: template.Name,
+			Body: bytes.NewReader(body),
+		}
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("error putting template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// indexBody returns the body of the create index request.
+func (c *Client) indexBody(definition api.IndexDefinition) indexBody {
+	return indexBody{
+		Settings: definition.Settings,
+		Mappings: definition.Mappings,
+		Aliases:  indexAliases(definition),
+	}
+}
+
+// templateBody returns the body of the put index or component template request.
+func templateBody(template api.Template) interface{} {
+	body := indexBody{
+		Settings: template.Definition.Settings,
+		Mappings: template.Definition.Mappings,
+	}
+
+	if template.Type == api.TemplateTypeComponent {
+		return componentTemplateBody{
+			Template: body,
+		}
+	}
+
+	indexTemplate := indexTemplateBody{
+		IndexPatterns: template.IndexPatterns,
+		Template:      body,
+	}
+	if template.DataStream {
+		indexTemplate.DataStream = &struct{}{}
+	}
+
+	return indexTemplate
+}
+
+// indexAliases returns the aliases of the created index.
+func indexAliases(definition api.IndexDefinition) map[string]aliasBody {
+	if definition.WriteAlias == "" {
+		return nil
+	}
+
+	return map[string]aliasBody{
+		definition.WriteAlias: {
+			IsWriteIndex: true,
+		},
+	}
+}
+
+// existsResponse interprets the response of an existence check.
+func existsResponse(res *esapi.Response) (bool, error) {
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if res.IsError() {
+		return false, errors.New(res.Status())
+	}
+
+	return true, nil
+}
+
+// errorResponse decodes the details of the failed response.
+func errorResponse(res *esapi.Response) (ErrorResponse, error) {
+	var errorDetails ErrorResponse
+	if err := json.NewDecoder(res.Body).Decode(&errorDetails); err != nil {
+		return ErrorResponse{}, errors.New(res.Status())
+	}
+
+	return errorDetails, nil
+}
+
+// responseError returns the error of the failed response.
+func responseError(res *esapi.Response) error {
+	errorDetails, err := errorResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason)
+}
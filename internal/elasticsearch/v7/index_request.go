@@ -0,0 +1,40 @@
+// Copyright © 2026 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v7
+
+import "encoding/json"
+
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.17/indices-create-index.html
+type indexBody struct {
+	Settings json.RawMessage      `json:"settings,omitempty"`
+	Mappings json.RawMessage      `json:"mappings,omitempty"`
+	Aliases  map[string]aliasBody `json:"aliases,omitempty"`
+}
+
+type aliasBody struct {
+	IsWriteIndex bool `json:"is_write_index,omitempty"`
+}
+
+// indexTemplateBody is the body of a composable index template, supported by Elasticsearch 7.8 and later.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.17/index-templates.html
+type indexTemplateBody struct {
+	IndexPatterns []string  `json:"index_patterns"`
+	DataStream    *struct{} `json:"data_stream,omitempty"`
+	Template      indexBody `json:"template"`
+}
+
+type componentTemplateBody struct {
+	Template indexBody `json:"template"`
+}
@@ -23,4 +23,11 @@ type config interface {
 	GetAPIKey() string
 	GetServiceToken() string
 	GetCertificateFingerprint() string
+	GetCompressRequestBody() bool
+	GetCompressionLevel() int
+	GetWriteMode() string
+	GetRetryOnConflict() int
+	GetScript() string
+	GetScriptID() string
+	GetScriptUpsert() string
 }
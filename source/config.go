@@ -39,6 +39,10 @@ type Config struct {
 	ServiceToken string `json:"serviceToken"`
 	// SHA256 hex fingerprint given by Elasticsearch on first launch.
 	CertificateFingerprint string `json:"certificateFingerprint"`
+	// Whether the request bodies are compressed with gzip.
+	CompressRequestBody bool `json:"compressRequestBody"`
+	// The gzip compression level of the request bodies, from `1` (best speed) to `9` (best compression). If `0`, the default level is used.
+	CompressionLevel int `json:"compressionLevel" validate:"gt=-1,lt=10"`
 	// The name of the indexes and sort details to read data from.
 	Indexes map[string]Sort `json:"indexes" validate:"required"`
 	// The number of items stored in bulk in the index. The minimum value is `1`, maximum value is `10000`.
@@ -84,6 +88,14 @@ func (c Config) GetCertificateFingerprint() string {
 	return c.CertificateFingerprint
 }
 
+func (c Config) GetCompressRequestBody() bool {
+	return c.CompressRequestBody
+}
+
+func (c Config) GetCompressionLevel() int {
+	return c.CompressionLevel
+}
+
 func (c Config) GetIndex() string {
 	return "" // Only for Config to implement the elasticsearch/internal/config
 }
@@ -91,3 +103,23 @@ func (c Config) GetIndex() string {
 func (c Config) GetType() string {
 	return "" // Only for Config to implement the elasticsearch/internal/config
 }
+
+func (c Config) GetWriteMode() string {
+	return "" // Only for Config to implement the elasticsearch/internal/config
+}
+
+func (c Config) GetRetryOnConflict() int {
+	return 0 // Only for Config to implement the elasticsearch/internal/config
+}
+
+func (c Config) GetScript() string {
+	return "" // Only for Config to implement the elasticsearch/internal/config
+}
+
+func (c Config) GetScriptID() string {
+	return "" // Only for Config to implement the elasticsearch/internal/config
+}
+
+func (c Config) GetScriptUpsert() string {
+	return "" // Only for Config to implement the elasticsearch/internal/config
+}
@@ -12,6 +12,8 @@ const (
 	ConfigBatchSize              = "batchSize"
 	ConfigCertificateFingerprint = "certificateFingerprint"
 	ConfigCloudID                = "cloudID"
+	ConfigCompressRequestBody    = "compressRequestBody"
+	ConfigCompressionLevel       = "compressionLevel"
 	ConfigHost                   = "host"
 	ConfigIndexesSortBy          = "indexes.*.sortBy"
 	ConfigIndexesSortOrder       = "indexes.*.sortOrder"
@@ -49,6 +51,21 @@ func (Config) Parameters() map[string]config.Parameter {
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigCompressRequestBody: {
+			Default:     "",
+			Description: "Whether the request bodies are compressed with gzip.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigCompressionLevel: {
+			Default:     "",
+			Description: "The gzip compression level of the request bodies, from `1` (best speed) to `9` (best compression). If `0`, the default level is used.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+				config.ValidationLessThan{V: 10},
+			},
+		},
 		ConfigHost: {
 			Default:     "",
 			Description: "The Elasticsearch host and port (e.g.: http://127.0.0.1:9200).",